@@ -0,0 +1,197 @@
+package goexpress
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// ClientIPOptions configures the client IP resolver created by NewClientIP.
+type ClientIPOptions struct {
+	// TrustedProxies lists the addresses of the trusted reverse proxies, as CIDR prefixes such
+	// as "10.0.0.0/8" or single IP addresses. The forwarding header is ignored when empty.
+	TrustedProxies []string
+
+	// Header is the forwarding header set by the trusted proxies, either "X-Forwarded-For" or
+	// the RFC 7239 "Forwarded" header. The other header is ignored, since clients can forge it
+	// through proxies that do not set it. X-Forwarded-For is used when empty.
+	Header string
+}
+
+// ClientIP resolves the IP address of the client of a request served behind trusted reverse proxies.
+type ClientIP struct {
+	trusted []netip.Prefix
+	header  string // canonical forwarding header set by the trusted proxies
+}
+
+// NewClientIP creates a client IP resolver with the given options. An error is returned if
+// a trusted proxy is not a valid CIDR prefix or IP address, or if the header is not supported.
+func NewClientIP(opts ClientIPOptions) (*ClientIP, error) {
+	c := &ClientIP{
+		trusted: make([]netip.Prefix, 0, len(opts.TrustedProxies)),
+		header:  http.CanonicalHeaderKey(opts.Header),
+	}
+
+	switch c.header {
+	case "":
+		c.header = "X-Forwarded-For"
+	case "X-Forwarded-For", "Forwarded":
+	default:
+		return nil, fmt.Errorf("goexpress: unsupported forwarding header %q, want X-Forwarded-For or Forwarded", opts.Header)
+	}
+
+	for _, proxy := range opts.TrustedProxies {
+		prefix, err := netip.ParsePrefix(proxy)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(proxy)
+			if addrErr != nil {
+				return nil, fmt.Errorf("goexpress: invalid trusted proxy %q: %w", proxy, err)
+			}
+			prefix = netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen())
+		}
+		c.trusted = append(c.trusted, prefix.Masked())
+	}
+	return c, nil
+}
+
+// Resolve returns the IP address of the client of the request.
+//
+// When the request comes from a trusted proxy, the hops of the forwarding header set by the
+// trusted proxies are walked from right to left, and the first address that is not a trusted
+// proxy is returned. Since each proxy appends the address it received the request from, the
+// entries added by the client itself are never used. An invalid entry stops the walk at the
+// last valid address. Otherwise, the address of RemoteAddr is returned.
+func (c *ClientIP) Resolve(r *http.Request) string {
+	remote := remoteIP(r)
+	addr, err := netip.ParseAddr(remote)
+	if err != nil || !c.isTrusted(addr) {
+		return remote
+	}
+
+	var hops []string
+	if c.header == "Forwarded" {
+		hops = forwardedFor(r.Header.Values(c.header))
+	} else {
+		hops = xForwardedFor(r.Header.Values(c.header))
+	}
+
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, ok := parseHop(hops[i])
+		if !ok {
+			break
+		}
+
+		addr = hop
+		if !c.isTrusted(addr) {
+			break
+		}
+	}
+	return addr.String()
+}
+
+// isTrusted reports whether the address is a trusted proxy.
+func (c *ClientIP) isTrusted(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range c.trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// RealIP stores the client IP address resolved by the resolver in the request context,
+// where it is used by LogRequest and RateLimitByIP, and available with ClientIPFromContext.
+// It must run before the middlewares using the address, e.g. as the first middleware.
+func RealIP(resolver *ClientIP) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), clientIPKey, resolver.Resolve(r))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// ClientIPFromContext returns the client IP address stored in the context by RealIP.
+func ClientIPFromContext(ctx context.Context) (string, bool) {
+	ip, ok := ctx.Value(clientIPKey).(string)
+	return ip, ok
+}
+
+// remoteIP returns the IP address of RemoteAddr, without the port.
+func remoteIP(r *http.Request) string {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return ip
+}
+
+// forwardedFor returns the values of the for parameters of the RFC 7239 Forwarded header values,
+// in the order of the hops.
+func forwardedFor(values []string) []string {
+	var hops []string
+	for _, value := range values {
+		for _, element := range splitQuoted(value, ',') {
+			for _, pair := range splitQuoted(element, ';') {
+				key, v, ok := strings.Cut(strings.TrimSpace(pair), "=")
+				if ok && strings.EqualFold(key, "for") {
+					hops = append(hops, strings.Trim(v, `"`))
+				}
+			}
+		}
+	}
+	return hops
+}
+
+// xForwardedFor returns the entries of the X-Forwarded-For header values, in the order of the hops.
+func xForwardedFor(values []string) []string {
+	var hops []string
+	for _, value := range values {
+		for _, hop := range strings.Split(value, ",") {
+			hops = append(hops, strings.TrimSpace(hop))
+		}
+	}
+	return hops
+}
+
+// splitQuoted splits s around the separator, ignoring the separators inside quoted strings.
+func splitQuoted(s string, sep byte) []string {
+	var (
+		parts  []string
+		quoted bool
+		start  int
+	)
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			quoted = !quoted
+		case '\\':
+			i++
+		case sep:
+			if !quoted {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// parseHop parses the address of a hop, such as 192.0.2.1, 192.0.2.1:4711, 2001:db8::1 or
+// [2001:db8::1]:4711. Obfuscated identifiers and "unknown" are not valid addresses.
+func parseHop(hop string) (netip.Addr, bool) {
+	if addr, err := netip.ParseAddr(hop); err == nil {
+		return addr.Unmap(), true
+	}
+	if addrPort, err := netip.ParseAddrPort(hop); err == nil {
+		return addrPort.Addr().Unmap(), true
+	}
+	if addr, err := netip.ParseAddr(strings.TrimSuffix(strings.TrimPrefix(hop, "["), "]")); err == nil {
+		return addr.Unmap(), true
+	}
+	return netip.Addr{}, false
+}
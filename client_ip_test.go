@@ -0,0 +1,181 @@
+package goexpress_test
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ferdiebergado/goexpress"
+)
+
+func TestClientIP(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		header     string
+		remoteAddr string
+		headers    map[string][]string
+		want       string
+	}{
+		{
+			name:       "untrusted remote address",
+			remoteAddr: "203.0.113.7:1234",
+			headers: map[string][]string{
+				"X-Forwarded-For": {"198.51.100.1"},
+				"X-Real-Ip":       {"198.51.100.2"},
+			},
+			want: "203.0.113.7",
+		},
+		{
+			name:       "trusted proxy without headers",
+			remoteAddr: "10.0.0.1:1234",
+			want:       "10.0.0.1",
+		},
+		{
+			name:       "rightmost untrusted X-Forwarded-For entry",
+			remoteAddr: "10.0.0.1:1234",
+			headers: map[string][]string{
+				"X-Forwarded-For": {"198.51.100.1, 203.0.113.9", "10.1.1.1"},
+			},
+			want: "203.0.113.9",
+		},
+		{
+			name:       "all entries trusted",
+			remoteAddr: "10.0.0.1:1234",
+			headers: map[string][]string{
+				"X-Forwarded-For": {"10.2.2.2, 10.1.1.1"},
+			},
+			want: "10.2.2.2",
+		},
+		{
+			name:       "invalid entry",
+			remoteAddr: "10.0.0.1:1234",
+			headers: map[string][]string{
+				"X-Forwarded-For": {"198.51.100.1, unknown, 10.1.1.1"},
+			},
+			want: "10.1.1.1",
+		},
+		{
+			name:       "forged forwarded header",
+			remoteAddr: "10.0.0.5:1234",
+			headers: map[string][]string{
+				"Forwarded":       {"for=6.6.6.6"},
+				"X-Forwarded-For": {"6.6.6.6, 203.0.113.9"},
+			},
+			want: "203.0.113.9",
+		},
+		{
+			name:       "forwarded header",
+			header:     "Forwarded",
+			remoteAddr: "[2001:db8::1]:443",
+			headers: map[string][]string{
+				"Forwarded": {`for=198.51.100.1;proto=https, For="[2001:db8:cafe::17]:4711";by=10.0.0.2`, "for=10.3.3.3:80"},
+			},
+			want: "2001:db8:cafe::17",
+		},
+		{
+			name:       "forged X-Forwarded-For header",
+			header:     "forwarded",
+			remoteAddr: "10.0.0.5:1234",
+			headers: map[string][]string{
+				"Forwarded":       {"for=203.0.113.9"},
+				"X-Forwarded-For": {"6.6.6.6"},
+			},
+			want: "203.0.113.9",
+		},
+		{
+			name:       "forwarded header with quoted separators",
+			header:     "Forwarded",
+			remoteAddr: "10.0.0.1:1234",
+			headers: map[string][]string{
+				"Forwarded": {`for=198.51.100.1;ext="a,b;c", for=10.4.4.4`},
+			},
+			want: "198.51.100.1",
+		},
+		{
+			name:       "obfuscated forwarded identifier",
+			header:     "Forwarded",
+			remoteAddr: "10.0.0.1:1234",
+			headers: map[string][]string{
+				"Forwarded": {"for=198.51.100.1, for=_hidden, for=10.5.5.5"},
+			},
+			want: "10.5.5.5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resolver, err := goexpress.NewClientIP(goexpress.ClientIPOptions{
+				TrustedProxies: []string{"10.0.0.0/8", "2001:db8::1"},
+				Header:         tt.header,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			req.RemoteAddr = tt.remoteAddr
+			for name, values := range tt.headers {
+				req.Header[name] = values
+			}
+
+			if got := resolver.Resolve(req); got != tt.want {
+				t.Errorf("Resolve() = %q, want: %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewClientIPInvalidProxy(t *testing.T) {
+	t.Parallel()
+
+	if _, err := goexpress.NewClientIP(goexpress.ClientIPOptions{TrustedProxies: []string{"10.0.0.0/33"}}); err == nil {
+		t.Error("NewClientIP() error = nil, want: error for an invalid CIDR prefix")
+	}
+}
+
+func TestNewClientIPInvalidHeader(t *testing.T) {
+	t.Parallel()
+
+	if _, err := goexpress.NewClientIP(goexpress.ClientIPOptions{Header: "X-Real-IP"}); err == nil {
+		t.Error("NewClientIP() error = nil, want: error for an unsupported header")
+	}
+}
+
+func TestRealIP(t *testing.T) {
+	t.Parallel()
+
+	resolver, err := goexpress.NewClientIP(goexpress.ClientIPOptions{TrustedProxies: []string{"10.0.0.1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lc := &logCapture{}
+	var got string
+	r := goexpress.New()
+	r.Use(goexpress.RealIP(resolver))
+	r.Use(goexpress.NewRequestLogger(goexpress.RequestLoggerOptions{Logger: slog.New(lc)}))
+	r.Get("/", http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		got, _ = goexpress.ClientIPFromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.RemoteAddr = "10.0.0.1:1234"
+	req.Header.Set("X-Forwarded-For", "198.51.100.1")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	const want = "198.51.100.1"
+	if got != want {
+		t.Errorf("ClientIPFromContext() = %q, want: %q", got, want)
+	}
+	if len(lc.entries) != 1 {
+		t.Fatalf("len(entries) = %d, want: 1", len(lc.entries))
+	}
+	if got := lc.entries[0]["remote_address"]; got != want {
+		t.Errorf("Logged remote_address = %v; want %v", got, want)
+	}
+}
@@ -0,0 +1,55 @@
+package goexpress
+
+import (
+	"context"
+	"net/http"
+)
+
+// contextKey is the type of the keys of the request context values set by the package.
+type contextKey int
+
+const (
+	routeKey     contextKey = iota // matched route
+	clientIPKey                    // client IP address resolved by RealIP
+	requestIDKey                   // request ID set by RequestID
+	loggerKey                      // request-scoped logger set by ContextLogger
+)
+
+// withRoute returns a copy of the request whose context holds the matched route.
+func withRoute(r *http.Request, rt *route) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), routeKey, rt))
+}
+
+// routeFromRequest returns the matched route stored in the request context, or nil.
+func routeFromRequest(r *http.Request) *route {
+	rt, _ := r.Context().Value(routeKey).(*route)
+	return rt
+}
+
+// RouteFromContext returns the description of the route matched by the request with the
+// context, such as its method, its pattern like /users/{id} and its name. The route is stored
+// in the context before the middlewares registered with Router.Use run. For a request served
+// by a mounted handler, it describes the mount; the routes of a mounted Router are visible
+// to the handlers and middlewares of that router. It reports false if no route was matched.
+//
+// The slices and the map of the returned RouteInfo are shared and must not be modified.
+func RouteFromContext(ctx context.Context) (RouteInfo, bool) {
+	rt, _ := ctx.Value(routeKey).(*route)
+	if rt == nil {
+		return RouteInfo{}, false
+	}
+	return rt.cachedInfo, true
+}
+
+// RouteMeta returns the metadata value for the given key of the route matched by the request
+// with the context, as set with Route.Meta. It reports false if the key is not set or no route
+// was matched, e.g. in the handlers of NotFound and MethodNotAllowed.
+func RouteMeta(ctx context.Context, key string) (any, bool) {
+	rt, _ := ctx.Value(routeKey).(*route)
+	if rt == nil {
+		return nil, false
+	}
+
+	value, ok := rt.metadata[key]
+	return value, ok
+}
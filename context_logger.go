@@ -0,0 +1,92 @@
+package goexpress
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"slices"
+)
+
+// scopedLogger is the request-scoped logger stored in the request context by ContextLogger.
+type scopedLogger struct {
+	logger *slog.Logger // logger with the attributes of the request
+	keys   []string     // keys of the attributes of the request
+}
+
+// ContextLogger returns a middleware that derives a logger from the base logger with the
+// attributes of the request, and stores it in the request context, where it is available
+// with Logger. The attributes are the request_id set by RequestID, the method, the route
+// pattern of the matched route and the remote_address of the client, resolved by RealIP.
+// The default slog logger is used as base logger when nil.
+//
+// LogRequest and RecoverPanic log with the logger of the request, without repeating its
+// attributes, unless a Logger is set in their options. The middleware must therefore run
+// after RequestID and RealIP, and before the logging middlewares.
+func ContextLogger(base *slog.Logger) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			logger := base
+			if logger == nil {
+				logger = slog.Default()
+			}
+
+			attrs := make([]slog.Attr, 0, 4)
+			if id, ok := RequestIDFromContext(r.Context()); ok {
+				attrs = append(attrs, slog.String("request_id", id))
+			}
+			attrs = append(attrs, slog.String("method", r.Method))
+			if ri, ok := RouteFromContext(r.Context()); ok {
+				attrs = append(attrs, slog.String("route", ri.label()))
+			}
+			attrs = append(attrs, slog.String("remote_address", getIPAddress(r)))
+
+			scoped := &scopedLogger{
+				logger: slog.New(logger.Handler().WithAttrs(attrs)),
+				keys:   make([]string, 0, len(attrs)),
+			}
+			for _, attr := range attrs {
+				scoped.keys = append(scoped.keys, attr.Key)
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), loggerKey, scoped)))
+		})
+	}
+}
+
+// Logger returns the logger of the request stored in the request context by ContextLogger,
+// or the default slog logger.
+func Logger(r *http.Request) *slog.Logger {
+	if scoped := scopedLoggerFrom(r.Context()); scoped != nil {
+		return scoped.logger
+	}
+	return slog.Default()
+}
+
+// scopedLoggerFrom returns the logger stored in the context by ContextLogger, or nil.
+func scopedLoggerFrom(ctx context.Context) *scopedLogger {
+	scoped, _ := ctx.Value(loggerKey).(*scopedLogger)
+	return scoped
+}
+
+// loggerFor returns the logger used by the logging middlewares for the request: the
+// logger of the options if set, or else the logger of the request. It also returns the keys
+// of the attributes that the logger already has.
+func loggerFor(r *http.Request, logger *slog.Logger) (*slog.Logger, []string) {
+	if logger != nil {
+		return logger, nil
+	}
+	if scoped := scopedLoggerFrom(r.Context()); scoped != nil {
+		return scoped.logger, scoped.keys
+	}
+	return slog.Default(), nil
+}
+
+// withoutKeys returns the attributes whose keys are not in keys.
+func withoutKeys(attrs []slog.Attr, keys []string) []slog.Attr {
+	if len(keys) == 0 {
+		return attrs
+	}
+	return slices.DeleteFunc(attrs, func(a slog.Attr) bool {
+		return slices.Contains(keys, a.Key)
+	})
+}
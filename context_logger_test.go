@@ -0,0 +1,76 @@
+package goexpress_test
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ferdiebergado/goexpress"
+)
+
+func TestContextLogger(t *testing.T) {
+	t.Parallel()
+
+	lc := &logCapture{}
+
+	r := goexpress.New()
+	r.Use(goexpress.RequestID)
+	r.Use(goexpress.ContextLogger(slog.New(lc)))
+	r.Use(goexpress.LogRequest)
+	r.Use(goexpress.RecoverPanic)
+	r.Get("/users/{id}", http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		goexpress.Logger(r).Info("loading user", "id", r.PathValue("id"))
+	}))
+	r.Get("/panic", http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		panic("test panic")
+	}))
+
+	for _, path := range []string{"/users/42", "/panic"} {
+		req := httptest.NewRequest(http.MethodGet, path, http.NoBody)
+		req.Header.Set(goexpress.DefaultRequestIDHeader, "req-1")
+		req.RemoteAddr = "192.0.2.1:1234"
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	wantMessages := []string{"loading user", "Request completed", "panic occurred", "Request completed"}
+	if len(lc.entries) != len(wantMessages) {
+		t.Fatalf("len(entries) = %d, want: %d", len(lc.entries), len(wantMessages))
+	}
+
+	wantRoutes := []string{"/users/{id}", "/users/{id}", "/panic", "/panic"}
+	for i, entry := range lc.entries {
+		if got := lc.messages[i]; got != wantMessages[i] {
+			t.Errorf("entries[%d] message = %v, want: %v", i, got, wantMessages[i])
+		}
+		if got := entry["request_id"]; got != "req-1" {
+			t.Errorf("entries[%d] request_id = %v, want: req-1", i, got)
+		}
+		if got := entry["method"]; got != http.MethodGet {
+			t.Errorf("entries[%d] method = %v, want: %v", i, got, http.MethodGet)
+		}
+		if got := entry["route"]; got != wantRoutes[i] {
+			t.Errorf("entries[%d] route = %v, want: %v", i, got, wantRoutes[i])
+		}
+		if got := entry["remote_address"]; got != "192.0.2.1" {
+			t.Errorf("entries[%d] remote_address = %v, want: 192.0.2.1", i, got)
+		}
+	}
+
+	for i, counts := range lc.counts {
+		for key, count := range counts {
+			if count > 1 {
+				t.Errorf("entries[%d] has %d %q attributes, want: 1", i, count, key)
+			}
+		}
+	}
+}
+
+func TestLoggerDefault(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	if got := goexpress.Logger(req); got != slog.Default() {
+		t.Errorf("Logger() = %v, want: slog.Default()", got)
+	}
+}
@@ -0,0 +1,217 @@
+package goexpress
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORSOptions configures the middleware returned by CORS.
+type CORSOptions struct {
+	// AllowedOrigins lists the origins allowed to make cross-origin requests. An entry is either
+	// an exact origin such as "https://example.com", a wildcard subdomain such as
+	// "https://*.example.com", or "*" to allow any origin.
+	// Any origin is allowed when both AllowedOrigins and AllowOriginFunc are empty.
+	// Any origin cannot be allowed along with AllowCredentials.
+	AllowedOrigins []string
+
+	// AllowOriginFunc, if set, reports whether an origin that is not listed in AllowedOrigins is allowed.
+	AllowOriginFunc func(origin string) bool
+
+	// AllowedMethods lists the methods allowed in cross-origin requests. Methods are uppercased.
+	// Defaults to GET, HEAD and POST.
+	AllowedMethods []string
+
+	// AllowedHeaders lists the non-simple request headers allowed in cross-origin requests.
+	// Use "*" to allow any header.
+	AllowedHeaders []string
+
+	// ExposedHeaders lists the response headers that the browser may expose to scripts.
+	ExposedHeaders []string
+
+	// AllowCredentials indicates whether the request can include user credentials
+	// like cookies, HTTP authentication or client side SSL certificates. The allowed origins
+	// must then be listed explicitly or accepted by AllowOriginFunc.
+	AllowCredentials bool
+
+	// MaxAge is how long the results of a preflight request can be cached. It is not sent when zero.
+	MaxAge time.Duration
+}
+
+// cors holds the resolved configuration of a CORS middleware.
+type cors struct {
+	allowAllOrigins  bool
+	origins          []string
+	wildcards        [][2]string // prefix and suffix of wildcard subdomain origins
+	allowOriginFunc  func(origin string) bool
+	methods          []string
+	allowAllHeaders  bool
+	headers          []string
+	allowMethods     string
+	exposedHeaders   string
+	allowCredentials bool
+	maxAge           string
+}
+
+// CORS returns a middleware that implements Cross-Origin Resource Sharing according to the given options.
+//
+// Preflight requests, i.e. OPTIONS requests with an Access-Control-Request-Method header,
+// are answered with a 204 (No Content) response without calling the next handler.
+// The middleware can be attached globally with Router.Use, where it also receives the preflight
+// requests answered as 405 (Method Not Allowed), or to a route group with its own policy.
+// Preflight requests only reach route group middlewares when the path has an Options route
+// or the router answers OPTIONS requests automatically (see Router.AutoOptions).
+//
+// It panics if AllowCredentials is set while any origin is allowed, since the credentials
+// of the users would then be exposed to every site.
+func CORS(opts CORSOptions) Middleware {
+	c := &cors{
+		allowOriginFunc:  opts.AllowOriginFunc,
+		exposedHeaders:   strings.Join(opts.ExposedHeaders, ", "),
+		allowCredentials: opts.AllowCredentials,
+	}
+
+	if len(opts.AllowedOrigins) == 0 && opts.AllowOriginFunc == nil {
+		c.allowAllOrigins = true
+	}
+	for _, origin := range opts.AllowedOrigins {
+		origin = strings.ToLower(origin)
+		switch {
+		case origin == "*":
+			c.allowAllOrigins = true
+		case strings.Contains(origin, "://*."):
+			prefix, suffix, _ := strings.Cut(origin, "*")
+			c.wildcards = append(c.wildcards, [2]string{prefix, suffix})
+		default:
+			c.origins = append(c.origins, origin)
+		}
+	}
+
+	if c.allowAllOrigins && opts.AllowCredentials {
+		panic("goexpress: CORS with AllowCredentials requires explicit AllowedOrigins or an AllowOriginFunc")
+	}
+
+	for _, method := range opts.AllowedMethods {
+		c.methods = append(c.methods, strings.ToUpper(method))
+	}
+	if len(c.methods) == 0 {
+		c.methods = []string{http.MethodGet, http.MethodHead, http.MethodPost}
+	}
+	c.allowMethods = strings.Join(c.methods, ", ")
+
+	for _, header := range opts.AllowedHeaders {
+		if header == "*" {
+			c.allowAllHeaders = true
+			continue
+		}
+		c.headers = append(c.headers, http.CanonicalHeaderKey(header))
+	}
+
+	if opts.MaxAge > 0 {
+		c.maxAge = strconv.Itoa(int(opts.MaxAge.Seconds()))
+	}
+
+	return c.middleware
+}
+
+// middleware wraps next with CORS handling.
+func (c *cors) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			c.preflight(w, r)
+			return
+		}
+
+		header := w.Header()
+		header.Add("Vary", "Origin")
+
+		if origin := r.Header.Get("Origin"); origin != "" && c.allowOrigin(origin) {
+			c.setAllowOrigin(header, origin)
+			if c.exposedHeaders != "" {
+				header.Set("Access-Control-Expose-Headers", c.exposedHeaders)
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// preflight answers a preflight request. The CORS headers are omitted when the request is not allowed.
+func (c *cors) preflight(w http.ResponseWriter, r *http.Request) {
+	header := w.Header()
+	header.Add("Vary", "Origin")
+	header.Add("Vary", "Access-Control-Request-Method")
+	header.Add("Vary", "Access-Control-Request-Headers")
+
+	origin := r.Header.Get("Origin")
+	method := r.Header.Get("Access-Control-Request-Method")
+	requestHeaders := r.Header.Get("Access-Control-Request-Headers")
+
+	if origin != "" && c.allowOrigin(origin) && c.allowMethod(method) && c.allowHeaders(requestHeaders) {
+		c.setAllowOrigin(header, origin)
+		header.Set("Access-Control-Allow-Methods", c.allowMethods)
+		if requestHeaders != "" {
+			header.Set("Access-Control-Allow-Headers", requestHeaders)
+		}
+		if c.maxAge != "" {
+			header.Set("Access-Control-Max-Age", c.maxAge)
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// setAllowOrigin sets the Access-Control-Allow-Origin and Access-Control-Allow-Credentials headers.
+func (c *cors) setAllowOrigin(header http.Header, origin string) {
+	if c.allowAllOrigins {
+		header.Set("Access-Control-Allow-Origin", "*")
+	} else {
+		header.Set("Access-Control-Allow-Origin", origin)
+	}
+
+	if c.allowCredentials {
+		header.Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+// allowOrigin reports whether cross-origin requests from the origin are allowed.
+func (c *cors) allowOrigin(origin string) bool {
+	if c.allowAllOrigins {
+		return true
+	}
+
+	lower := strings.ToLower(origin)
+	if slices.Contains(c.origins, lower) {
+		return true
+	}
+	for _, w := range c.wildcards {
+		prefix, suffix := w[0], w[1]
+		if len(lower) > len(prefix)+len(suffix) && strings.HasPrefix(lower, prefix) && strings.HasSuffix(lower, suffix) {
+			return true
+		}
+	}
+
+	return c.allowOriginFunc != nil && c.allowOriginFunc(origin)
+}
+
+// allowMethod reports whether cross-origin requests with the method are allowed.
+func (c *cors) allowMethod(method string) bool {
+	return slices.Contains(c.methods, method)
+}
+
+// allowHeaders reports whether all the headers in the comma-separated list are allowed.
+func (c *cors) allowHeaders(list string) bool {
+	if c.allowAllHeaders {
+		return true
+	}
+
+	for _, header := range strings.Split(list, ",") {
+		header = strings.TrimSpace(header)
+		if header != "" && !slices.Contains(c.headers, http.CanonicalHeaderKey(header)) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,308 @@
+package goexpress_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ferdiebergado/goexpress"
+)
+
+func TestCORS(t *testing.T) {
+	t.Parallel()
+
+	const (
+		allowOrigin      = "Access-Control-Allow-Origin"
+		allowMethods     = "Access-Control-Allow-Methods"
+		allowHeaders     = "Access-Control-Allow-Headers"
+		allowCredentials = "Access-Control-Allow-Credentials"
+		exposeHeaders    = "Access-Control-Expose-Headers"
+		maxAge           = "Access-Control-Max-Age"
+	)
+
+	opts := goexpress.CORSOptions{
+		AllowedOrigins: []string{"https://example.com", "https://*.example.org"},
+		AllowOriginFunc: func(origin string) bool {
+			return origin == "https://trusted.test"
+		},
+		AllowedMethods:   []string{http.MethodGet, http.MethodPut},
+		AllowedHeaders:   []string{"Content-Type", "X-Token"},
+		ExposedHeaders:   []string{"X-Total-Count"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	}
+
+	tests := []struct {
+		name        string
+		opts        goexpress.CORSOptions
+		method      string
+		headers     map[string]string
+		wantStatus  int
+		wantBody    string
+		wantHeaders map[string]string
+	}{
+		{
+			name:       "exact origin",
+			opts:       opts,
+			method:     http.MethodGet,
+			headers:    map[string]string{"Origin": "https://example.com"},
+			wantStatus: http.StatusOK,
+			wantBody:   "ok",
+			wantHeaders: map[string]string{
+				allowOrigin:      "https://example.com",
+				allowCredentials: "true",
+				exposeHeaders:    "X-Total-Count",
+				"Vary":           "Origin",
+			},
+		},
+		{
+			name:       "wildcard subdomain",
+			opts:       opts,
+			method:     http.MethodGet,
+			headers:    map[string]string{"Origin": "https://api.example.org"},
+			wantStatus: http.StatusOK,
+			wantBody:   "ok",
+			wantHeaders: map[string]string{
+				allowOrigin: "https://api.example.org",
+			},
+		},
+		{
+			name:       "wildcard does not match the bare domain",
+			opts:       opts,
+			method:     http.MethodGet,
+			headers:    map[string]string{"Origin": "https://example.org"},
+			wantStatus: http.StatusOK,
+			wantBody:   "ok",
+			wantHeaders: map[string]string{
+				allowOrigin: "",
+			},
+		},
+		{
+			name:       "origin predicate",
+			opts:       opts,
+			method:     http.MethodGet,
+			headers:    map[string]string{"Origin": "https://trusted.test"},
+			wantStatus: http.StatusOK,
+			wantBody:   "ok",
+			wantHeaders: map[string]string{
+				allowOrigin: "https://trusted.test",
+			},
+		},
+		{
+			name:       "disallowed origin",
+			opts:       opts,
+			method:     http.MethodGet,
+			headers:    map[string]string{"Origin": "https://evil.test"},
+			wantStatus: http.StatusOK,
+			wantBody:   "ok",
+			wantHeaders: map[string]string{
+				allowOrigin: "",
+				"Vary":      "Origin",
+			},
+		},
+		{
+			name:       "any origin",
+			opts:       goexpress.CORSOptions{},
+			method:     http.MethodGet,
+			headers:    map[string]string{"Origin": "https://any.test"},
+			wantStatus: http.StatusOK,
+			wantBody:   "ok",
+			wantHeaders: map[string]string{
+				allowOrigin:      "*",
+				allowCredentials: "",
+			},
+		},
+		{
+			name:   "preflight",
+			opts:   opts,
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                         "https://example.com",
+				"Access-Control-Request-Method":  http.MethodPut,
+				"Access-Control-Request-Headers": "content-type, x-token",
+			},
+			wantStatus: http.StatusNoContent,
+			wantHeaders: map[string]string{
+				allowOrigin:      "https://example.com",
+				allowMethods:     "GET, PUT",
+				allowHeaders:     "content-type, x-token",
+				allowCredentials: "true",
+				maxAge:           "600",
+			},
+		},
+		{
+			name: "preflight with lowercase allowed methods",
+			opts: goexpress.CORSOptions{
+				AllowedOrigins: []string{"https://example.com"},
+				AllowedMethods: []string{"get", "put"},
+			},
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                        "https://example.com",
+				"Access-Control-Request-Method": http.MethodPut,
+			},
+			wantStatus: http.StatusNoContent,
+			wantHeaders: map[string]string{
+				allowOrigin:  "https://example.com",
+				allowMethods: "GET, PUT",
+			},
+		},
+		{
+			name:   "preflight with disallowed method",
+			opts:   opts,
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                        "https://example.com",
+				"Access-Control-Request-Method": http.MethodDelete,
+			},
+			wantStatus: http.StatusNoContent,
+			wantHeaders: map[string]string{
+				allowOrigin:  "",
+				allowMethods: "",
+			},
+		},
+		{
+			name:   "preflight with disallowed header",
+			opts:   opts,
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                         "https://example.com",
+				"Access-Control-Request-Method":  http.MethodGet,
+				"Access-Control-Request-Headers": "X-Forbidden",
+			},
+			wantStatus: http.StatusNoContent,
+			wantHeaders: map[string]string{
+				allowOrigin: "",
+			},
+		},
+		{
+			name:       "options without preflight headers",
+			opts:       opts,
+			method:     http.MethodOptions,
+			headers:    map[string]string{"Origin": "https://example.com"},
+			wantStatus: http.StatusOK,
+			wantBody:   "ok",
+			wantHeaders: map[string]string{
+				allowOrigin: "https://example.com",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Write([]byte("ok"))
+			})
+
+			req := httptest.NewRequest(tt.method, "/todos", http.NoBody)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			goexpress.CORS(tt.opts)(handler).ServeHTTP(rec, req)
+
+			assertStatus(t, rec.Code, tt.wantStatus)
+			assertBody(t, rec.Body.String(), tt.wantBody)
+			for k, v := range tt.wantHeaders {
+				assertHeader(t, rec, k, v)
+			}
+		})
+	}
+}
+
+func TestCORSGroup(t *testing.T) {
+	t.Parallel()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("ok"))
+	})
+
+	r := goexpress.New()
+	r.AutoOptions(true)
+	r.Get("/", handler)
+	r.Group("/api", func(api *goexpress.Router) {
+		api.Put("/todos/{id}", handler)
+	}, goexpress.CORS(goexpress.CORSOptions{
+		AllowedOrigins: []string{"https://app.example.com"},
+		AllowedMethods: []string{http.MethodPut},
+	}))
+
+	req := httptest.NewRequest(http.MethodOptions, "/api/todos/1", http.NoBody)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPut)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assertStatus(t, rec.Code, http.StatusNoContent)
+	assertHeader(t, rec, "Access-Control-Allow-Origin", "https://app.example.com")
+	assertHeader(t, rec, "Access-Control-Allow-Methods", http.MethodPut)
+
+	req = httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set("Origin", "https://app.example.com")
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assertStatus(t, rec.Code, http.StatusOK)
+	assertHeader(t, rec, "Access-Control-Allow-Origin", "")
+}
+
+func TestCORSCredentialsWithAnyOrigin(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		opts goexpress.CORSOptions
+	}{
+		{
+			name: "default origins",
+			opts: goexpress.CORSOptions{AllowCredentials: true},
+		},
+		{
+			name: "wildcard origin",
+			opts: goexpress.CORSOptions{
+				AllowedOrigins:   []string{"https://example.com", "*"},
+				AllowCredentials: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			defer func() {
+				if recover() == nil {
+					t.Error("CORS with credentials and any origin did not panic")
+				}
+			}()
+
+			goexpress.CORS(tt.opts)
+		})
+	}
+}
+
+func TestCORSGlobalPreflight(t *testing.T) {
+	t.Parallel()
+
+	r := goexpress.New()
+	r.Use(goexpress.CORS(goexpress.CORSOptions{
+		AllowedOrigins: []string{"https://app.example.com"},
+		AllowedMethods: []string{http.MethodGet, http.MethodPut},
+	}))
+	r.Put("/todos/{id}", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+
+	req := httptest.NewRequest(http.MethodOptions, "/todos/1", http.NoBody)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPut)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assertStatus(t, rec.Code, http.StatusNoContent)
+	assertHeader(t, rec, "Access-Control-Allow-Origin", "https://app.example.com")
+	assertHeader(t, rec, "Access-Control-Allow-Methods", "GET, PUT")
+}
@@ -1,6 +1,7 @@
 package goexpress_test
 
 import (
+	"fmt"
 	"log"
 	"net/http"
 
@@ -141,3 +142,19 @@ func ExampleRouter_NotFound() {
 		http.Error(w, "Custom 404 - Page Not Found", http.StatusNotFound)
 	}))
 }
+
+func ExampleRouter_URL() {
+	router := goexpress.New()
+
+	router.Get("/users/{id}/posts", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("posts of user: " + r.PathValue("id")))
+	})).Name("user-posts")
+
+	url, err := router.URL("user-posts", "id", "42")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(url)
+	// Output: /users/42/posts
+}
@@ -0,0 +1,125 @@
+package goexpress
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Host creates a new route group for the requests to the given host and applies the given
+// function to define the routes of the host. Routes can be specified just like with the
+// normal router, and middlewares for the host can be specified as the last arguments.
+//
+// The host pattern is either an exact host such as "api.example.com", registered as a
+// host-qualified http.ServeMux pattern, or contains wildcard labels such as
+// "{tenant}.example.com", whose values are available with http.Request.PathValue.
+// Requests that match no route of the host are served by the routes for any host.
+func (r *Router) Host(pattern string, fn func(*Router), middlewares ...Middleware) {
+	r.mustNotServe()
+
+	sub := &Router{
+		prefix:      r.prefix,
+		host:        strings.ToLower(pattern),
+		parent:      r,
+		middlewares: slices.Clone(middlewares),
+		core:        r.core,
+	}
+
+	if strings.ContainsAny(pattern, "{}") {
+		sub.host = pattern
+		sub.hostMux = r.core.hostMux(pattern)
+	}
+
+	fn(sub)
+}
+
+// hostMux returns the mux of the host pattern with wildcards, creating it if needed.
+func (c *routerCore) hostMux(pattern string) *hostMux {
+	for _, h := range c.hosts {
+		if h.pattern == pattern {
+			return h
+		}
+	}
+
+	h := newHostMux(pattern)
+	c.hosts = append(c.hosts, h)
+	return h
+}
+
+// hostMux routes the requests to a host pattern with wildcards, which http.ServeMux does not support.
+type hostMux struct {
+	pattern string         // host pattern, e.g. {tenant}.example.com
+	labels  []string       // labels of the host pattern
+	mux     *http.ServeMux // mux of the routes of the host
+}
+
+// newHostMux returns the mux of the host pattern. It panics if a wildcard is not a whole label.
+func newHostMux(pattern string) *hostMux {
+	labels := strings.Split(pattern, ".")
+	names := make([]string, 0, len(labels))
+	for i, label := range labels {
+		if !strings.ContainsAny(label, "{}") {
+			labels[i] = strings.ToLower(label)
+			continue
+		}
+
+		name, ok := wildcardName(label)
+		if !ok {
+			panic(fmt.Sprintf("goexpress: host pattern %q: wildcard %q must be a whole label like {name}", pattern, label))
+		}
+		if slices.Contains(names, name) {
+			panic(fmt.Sprintf("goexpress: host pattern %q: duplicate wildcard %q", pattern, name))
+		}
+		names = append(names, name)
+	}
+
+	return &hostMux{
+		pattern: pattern,
+		labels:  labels,
+		mux:     http.NewServeMux(),
+	}
+}
+
+// match reports whether the host matches the pattern, and returns the values of its wildcards.
+func (h *hostMux) match(host string) (map[string]string, bool) {
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+
+	labels := strings.Split(strings.ToLower(host), ".")
+	if len(labels) != len(h.labels) {
+		return nil, false
+	}
+
+	values := make(map[string]string)
+	for i, label := range h.labels {
+		if name, ok := wildcardName(label); ok {
+			if labels[i] == "" {
+				return nil, false
+			}
+			values[name] = labels[i]
+			continue
+		}
+
+		if labels[i] != label {
+			return nil, false
+		}
+	}
+
+	return values, true
+}
+
+// wildcardName returns the name of the wildcard label {name}.
+func wildcardName(label string) (string, bool) {
+	if len(label) < 3 || label[0] != '{' || label[len(label)-1] != '}' {
+		return "", false
+	}
+
+	name := label[1 : len(label)-1]
+	if strings.ContainsAny(name, "{}") {
+		return "", false
+	}
+	return name, true
+}
@@ -0,0 +1,185 @@
+package goexpress_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ferdiebergado/goexpress"
+)
+
+func TestHost(t *testing.T) {
+	t.Parallel()
+
+	const header = "X-Middleware"
+
+	hostMw := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(header, "host")
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	r := goexpress.New()
+	r.Get("/status", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("any host"))
+	}))
+	r.Host("api.example.com", func(api *goexpress.Router) {
+		api.Get("/status", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Write([]byte("api"))
+		}))
+	})
+	r.Host("{tenant}.admin.example.com", func(admin *goexpress.Router) {
+		admin.Group("/users", func(users *goexpress.Router) {
+			users.Get("/{id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(r.PathValue("tenant") + " user " + r.PathValue("id")))
+			}))
+		})
+	}, hostMw)
+
+	tests := []struct {
+		name       string
+		method     string
+		host       string
+		path       string
+		wantStatus int
+		wantBody   string
+		wantHeader string
+	}{
+		{
+			name:       "exact host",
+			method:     http.MethodGet,
+			host:       "api.example.com",
+			path:       "/status",
+			wantStatus: http.StatusOK,
+			wantBody:   "api",
+		},
+		{
+			name:       "exact host with port",
+			method:     http.MethodGet,
+			host:       "api.example.com:8080",
+			path:       "/status",
+			wantStatus: http.StatusOK,
+			wantBody:   "api",
+		},
+		{
+			name:       "other host",
+			method:     http.MethodGet,
+			host:       "www.example.com",
+			path:       "/status",
+			wantStatus: http.StatusOK,
+			wantBody:   "any host",
+		},
+		{
+			name:       "wildcard subdomain",
+			method:     http.MethodGet,
+			host:       "acme.admin.example.com",
+			path:       "/users/42",
+			wantStatus: http.StatusOK,
+			wantBody:   "acme user 42",
+			wantHeader: "host",
+		},
+		{
+			name:       "wildcard subdomain falls back to any host",
+			method:     http.MethodGet,
+			host:       "acme.admin.example.com",
+			path:       "/status",
+			wantStatus: http.StatusOK,
+			wantBody:   "any host",
+		},
+		{
+			name:       "wildcard subdomain method not allowed",
+			method:     http.MethodDelete,
+			host:       "acme.admin.example.com",
+			path:       "/users/42",
+			wantStatus: http.StatusMethodNotAllowed,
+			wantBody:   "Method Not Allowed",
+		},
+		{
+			name:       "wildcard does not match nested subdomains",
+			method:     http.MethodGet,
+			host:       "a.b.admin.example.com",
+			path:       "/users/42",
+			wantStatus: http.StatusNotFound,
+			wantBody:   "404 page not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(tt.method, tt.path, http.NoBody)
+			req.Host = tt.host
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assertStatus(t, rec.Code, tt.wantStatus)
+			assertBody(t, rec.Body.String(), tt.wantBody)
+			assertHeader(t, rec, header, tt.wantHeader)
+		})
+	}
+
+	routes := r.Routes()
+	wantHosts := []string{"", "api.example.com", "{tenant}.admin.example.com"}
+	if len(routes) != len(wantHosts) {
+		t.Fatalf("len(r.Routes()) = %d, want: %d", len(routes), len(wantHosts))
+	}
+	for i, want := range wantHosts {
+		if got := routes[i].Host; got != want {
+			t.Errorf("routes[%d].Host = %q, want: %q", i, got, want)
+		}
+	}
+}
+
+func TestHostInvalidWildcard(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if err := recover(); err == nil {
+			t.Error("invalid host wildcard did not panic")
+		}
+	}()
+
+	goexpress.New().Host("api-{tenant}.example.com", func(*goexpress.Router) {})
+}
+
+func TestHostURL(t *testing.T) {
+	t.Parallel()
+
+	r := goexpress.New()
+	r.Host("{tenant}.example.com", func(tenant *goexpress.Router) {
+		tenant.Get("/dash", http.NotFoundHandler()).Name("dash")
+	})
+
+	if got, err := r.URL("dash"); err != nil || got != "/dash" {
+		t.Errorf(`r.URL("dash") = %q, %v, want: "/dash", <nil>`, got, err)
+	}
+
+	_, err := r.URL("dash", "tenant", "acme")
+	if !errors.Is(err, goexpress.ErrExtraParam) || !strings.Contains(err.Error(), "host") {
+		t.Errorf(`r.URL("dash", "tenant", "acme") error = %v, want: %v for a host wildcard`, err, goexpress.ErrExtraParam)
+	}
+}
+
+func TestHostMountRoutes(t *testing.T) {
+	t.Parallel()
+
+	users := goexpress.New()
+	users.Get("/{id}", http.NotFoundHandler())
+
+	r := goexpress.New()
+	r.Host("api.example.com", func(api *goexpress.Router) {
+		api.Mount("/users", users)
+	})
+
+	routes := r.Routes()
+	if len(routes) != 1 {
+		t.Fatalf("len(r.Routes()) = %d, want: 1", len(routes))
+	}
+	if got, want := routes[0].Host+routes[0].Pattern, "api.example.com/users/{id}"; got != want {
+		t.Errorf("routes[0].Host+Pattern = %q, want: %q", got, want)
+	}
+}
@@ -0,0 +1,276 @@
+package goexpress
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Default histogram buckets of the metrics.
+var (
+	// DefaultDurationBuckets are the default buckets of the request duration histogram, in seconds.
+	DefaultDurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+	// DefaultSizeBuckets are the default buckets of the response size histogram, in bytes.
+	DefaultSizeBuckets = []float64{100, 1_000, 10_000, 100_000, 1_000_000, 10_000_000}
+)
+
+// unmatchedRoute is the route label of the requests that matched no route.
+const unmatchedRoute = "unmatched"
+
+// MetricsOptions configures the metrics created by NewMetrics.
+type MetricsOptions struct {
+	// Namespace is prepended to the metric names with an underscore, e.g. myapp_http_requests_total.
+	Namespace string
+
+	// DurationBuckets are the upper bounds of the request duration histogram buckets, in seconds.
+	// DefaultDurationBuckets is used when empty.
+	DurationBuckets []float64
+
+	// SizeBuckets are the upper bounds of the response size histogram buckets, in bytes.
+	// DefaultSizeBuckets is used when empty.
+	SizeBuckets []float64
+}
+
+// Metrics records the metrics of the requests served through its Middleware, and exposes
+// them in the Prometheus text exposition format as an http.Handler:
+//
+//	metrics := goexpress.NewMetrics(goexpress.MetricsOptions{})
+//	router.Use(metrics.Middleware)
+//	router.Get("/metrics", metrics)
+//
+// The metrics are labelled by method, matched route pattern and status class (2xx, 4xx, ...):
+//
+//	http_requests_total              counter of the completed requests
+//	http_requests_in_flight          gauge of the requests being served, without status label
+//	http_request_duration_seconds    histogram of the request durations
+//	http_response_size_bytes         histogram of the response body sizes
+//
+// The route label is the pattern reported by RouteFromContext, prefixed by the host pattern for
+// the routes of a host, or "unmatched". It is the route logged by LogRequest. Since the route
+// is only known to the middlewares registered with Router.Use, the middleware must be registered
+// with Use rather than wrapping the router. Non-standard methods are labelled "OTHER" to bound
+// the number of series.
+type Metrics struct {
+	namespace       string
+	durationBuckets []float64
+	sizeBuckets     []float64
+
+	mu       sync.Mutex
+	inFlight map[metricLabels]int64
+	series   map[metricLabels]*metricSeries
+}
+
+// metricLabels are the label values of a metric series.
+type metricLabels struct {
+	method string
+	route  string
+	status string // status class, empty for the in-flight gauge
+}
+
+// metricSeries holds the values of the metrics of completed requests with the same labels.
+type metricSeries struct {
+	count    uint64
+	duration histogram
+	size     histogram
+}
+
+// histogram holds the observations of a histogram. counts[i] is the number of observations
+// less than or equal to the upper bound of bucket i and greater than the previous bound.
+type histogram struct {
+	counts []uint64
+	sum    float64
+}
+
+// observe adds the value to the histogram with the given bucket upper bounds.
+func (h *histogram) observe(bounds []float64, v float64) {
+	if h.counts == nil {
+		h.counts = make([]uint64, len(bounds)+1)
+	}
+
+	i, _ := slices.BinarySearch(bounds, v)
+	h.counts[i]++
+	h.sum += v
+}
+
+// NewMetrics creates the metrics with the given options.
+func NewMetrics(opts MetricsOptions) *Metrics {
+	return &Metrics{
+		namespace:       opts.Namespace,
+		durationBuckets: sortedBuckets(opts.DurationBuckets, DefaultDurationBuckets),
+		sizeBuckets:     sortedBuckets(opts.SizeBuckets, DefaultSizeBuckets),
+		inFlight:        make(map[metricLabels]int64),
+		series:          make(map[metricLabels]*metricSeries),
+	}
+}
+
+// sortedBuckets returns a sorted copy of the buckets without duplicates, or the default buckets if empty.
+func sortedBuckets(buckets, defaults []float64) []float64 {
+	if len(buckets) == 0 {
+		buckets = defaults
+	}
+
+	sorted := slices.Clone(buckets)
+	slices.Sort(sorted)
+	return slices.Compact(sorted)
+}
+
+// Middleware records the metrics of the requests.
+func (m *Metrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		labels := metricLabels{method: metricMethod(r.Method), route: unmatchedRoute}
+		if ri, ok := RouteFromContext(r.Context()); ok {
+			labels.route = ri.label()
+		}
+
+		m.mu.Lock()
+		m.inFlight[labels]++
+		m.mu.Unlock()
+
+		start := time.Now()
+		rw := newResponseWriter(w)
+		completed := false
+
+		defer func() {
+			duration := time.Since(start).Seconds()
+
+			m.mu.Lock()
+			defer m.mu.Unlock()
+
+			m.inFlight[labels]--
+
+			status := rw.Status()
+			// A panicking handler is answered with 500 by a recoverer, unless it started the response.
+			if !completed && !rw.wroteHeader {
+				status = http.StatusInternalServerError
+			}
+			labels.status = strconv.Itoa(status/100) + "xx"
+			s, ok := m.series[labels]
+			if !ok {
+				s = &metricSeries{}
+				m.series[labels] = s
+			}
+			s.count++
+			s.duration.observe(m.durationBuckets, duration)
+			s.size.observe(m.sizeBuckets, float64(rw.bytes))
+		}()
+
+		next.ServeHTTP(rw, r)
+		completed = true
+	})
+}
+
+// metricMethod returns the method label of the request method.
+func metricMethod(method string) string {
+	if slices.Contains(anyMethods, method) {
+		return method
+	}
+	return "OTHER"
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	var b strings.Builder
+	m.writeTo(&b)
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	io.WriteString(w, b.String()) //nolint:errcheck // the client is gone when the write fails
+}
+
+// writeTo writes the metrics in the Prometheus text exposition format to b.
+func (m *Metrics) writeTo(b *strings.Builder) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	total := m.name("http_requests_total")
+	writeMetricHeader(b, total, "counter", "Total number of completed HTTP requests.")
+	for _, labels := range sortedLabels(m.series) {
+		fmt.Fprintf(b, "%s{%s} %d\n", total, labels, m.series[labels].count)
+	}
+
+	inFlight := m.name("http_requests_in_flight")
+	writeMetricHeader(b, inFlight, "gauge", "Number of HTTP requests being served.")
+	for _, labels := range sortedLabels(m.inFlight) {
+		fmt.Fprintf(b, "%s{%s} %d\n", inFlight, labels, m.inFlight[labels])
+	}
+
+	duration := m.name("http_request_duration_seconds")
+	writeMetricHeader(b, duration, "histogram", "Duration of the HTTP requests in seconds.")
+	for _, labels := range sortedLabels(m.series) {
+		writeHistogram(b, duration, labels, m.durationBuckets, &m.series[labels].duration)
+	}
+
+	size := m.name("http_response_size_bytes")
+	writeMetricHeader(b, size, "histogram", "Size of the HTTP response bodies in bytes.")
+	for _, labels := range sortedLabels(m.series) {
+		writeHistogram(b, size, labels, m.sizeBuckets, &m.series[labels].size)
+	}
+}
+
+// name returns the metric name with the namespace.
+func (m *Metrics) name(name string) string {
+	if m.namespace == "" {
+		return name
+	}
+	return m.namespace + "_" + name
+}
+
+// writeMetricHeader writes the HELP and TYPE lines of a metric.
+func writeMetricHeader(b *strings.Builder, name, typ, help string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+// writeHistogram writes the cumulative buckets, the sum and the count of a histogram series.
+func writeHistogram(b *strings.Builder, name string, labels metricLabels, bounds []float64, h *histogram) {
+	var cumulative uint64
+	for i, bound := range bounds {
+		cumulative += h.counts[i]
+		fmt.Fprintf(b, "%s_bucket{%s,le=%q} %d\n", name, labels, formatFloat(bound), cumulative)
+	}
+	cumulative += h.counts[len(bounds)]
+
+	fmt.Fprintf(b, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, labels, cumulative)
+	fmt.Fprintf(b, "%s_sum{%s} %s\n", name, labels, formatFloat(h.sum))
+	fmt.Fprintf(b, "%s_count{%s} %d\n", name, labels, cumulative)
+}
+
+// sortedLabels returns the labels of the series in a stable order.
+func sortedLabels[V any](series map[metricLabels]V) []metricLabels {
+	labels := make([]metricLabels, 0, len(series))
+	for l := range series {
+		labels = append(labels, l)
+	}
+
+	slices.SortFunc(labels, func(a, b metricLabels) int {
+		return cmp.Or(cmp.Compare(a.route, b.route), cmp.Compare(a.method, b.method), cmp.Compare(a.status, b.status))
+	})
+	return labels
+}
+
+// String formats the labels as in the Prometheus text exposition format.
+func (l metricLabels) String() string {
+	s := "method=" + quoteLabel(l.method) + ",route=" + quoteLabel(l.route)
+	if l.status != "" {
+		s += ",status=" + quoteLabel(l.status)
+	}
+	return s
+}
+
+// labelEscaper escapes label values as required by the Prometheus text exposition format.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// quoteLabel returns the quoted and escaped label value.
+func quoteLabel(v string) string {
+	return `"` + labelEscaper.Replace(v) + `"`
+}
+
+// formatFloat formats the float as in the Prometheus text exposition format.
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,142 @@
+package goexpress_test
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ferdiebergado/goexpress"
+)
+
+func TestMetrics(t *testing.T) {
+	t.Parallel()
+
+	metrics := goexpress.NewMetrics(goexpress.MetricsOptions{
+		Namespace:   "app",
+		SizeBuckets: []float64{10, 1},
+	})
+
+	r := goexpress.New()
+	r.Use(metrics.Middleware)
+	r.Get("/users/{id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") == "0" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("user"))
+	}))
+	r.Get("/metrics", metrics)
+
+	for _, path := range []string{"/users/1", "/users/2", "/users/0"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, http.NoBody))
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assertStatus(t, rec.Code, http.StatusOK)
+	assertHeader(t, rec, "Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	body := rec.Body.String()
+	wantLines := []string{
+		"# TYPE app_http_requests_total counter",
+		`app_http_requests_total{method="GET",route="/users/{id}",status="2xx"} 2`,
+		`app_http_requests_total{method="GET",route="/users/{id}",status="4xx"} 1`,
+		"# TYPE app_http_requests_in_flight gauge",
+		`app_http_requests_in_flight{method="GET",route="/metrics"} 1`,
+		`app_http_requests_in_flight{method="GET",route="/users/{id}"} 0`,
+		"# TYPE app_http_request_duration_seconds histogram",
+		`app_http_request_duration_seconds_bucket{method="GET",route="/users/{id}",status="2xx",le="+Inf"} 2`,
+		`app_http_request_duration_seconds_count{method="GET",route="/users/{id}",status="2xx"} 2`,
+		"# TYPE app_http_response_size_bytes histogram",
+		`app_http_response_size_bytes_bucket{method="GET",route="/users/{id}",status="2xx",le="1"} 0`,
+		`app_http_response_size_bytes_bucket{method="GET",route="/users/{id}",status="2xx",le="10"} 2`,
+		`app_http_response_size_bytes_bucket{method="GET",route="/users/{id}",status="4xx",le="1"} 0`,
+		`app_http_response_size_bytes_bucket{method="GET",route="/users/{id}",status="4xx",le="+Inf"} 1`,
+		`app_http_response_size_bytes_sum{method="GET",route="/users/{id}",status="2xx"} 8`,
+	}
+	for _, line := range wantLines {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("metrics do not contain %q:\n%s", line, body)
+		}
+	}
+}
+
+func TestMetricsLabels(t *testing.T) {
+	t.Parallel()
+
+	metrics := goexpress.NewMetrics(goexpress.MetricsOptions{})
+
+	r := goexpress.New()
+	r.Use(metrics.Middleware)
+	r.NotFound(http.NotFoundHandler())
+
+	req := httptest.NewRequest("PURGE", "/missing", http.NoBody)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	rec := httptest.NewRecorder()
+	metrics.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody))
+
+	want := `http_requests_total{method="OTHER",route="/",status="4xx"} 1`
+	if !strings.Contains(rec.Body.String(), want+"\n") {
+		t.Errorf("metrics do not contain %q:\n%s", want, rec.Body.String())
+	}
+}
+
+func TestMetricsPanic(t *testing.T) {
+	t.Parallel()
+
+	metrics := goexpress.NewMetrics(goexpress.MetricsOptions{})
+
+	r := goexpress.New()
+	r.Use(goexpress.NewRecoverer(goexpress.RecovererOptions{
+		Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}))
+	r.Use(metrics.Middleware)
+	r.Get("/boom", http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		panic("test panic")
+	}))
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/boom", http.NoBody))
+	assertStatus(t, rec.Code, http.StatusInternalServerError)
+
+	rec = httptest.NewRecorder()
+	metrics.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody))
+
+	want := `http_requests_total{method="GET",route="/boom",status="5xx"} 1`
+	if !strings.Contains(rec.Body.String(), want+"\n") {
+		t.Errorf("metrics do not contain %q:\n%s", want, rec.Body.String())
+	}
+}
+
+func TestMetricsUnmatched(t *testing.T) {
+	t.Parallel()
+
+	metrics := goexpress.NewMetrics(goexpress.MetricsOptions{})
+
+	r := goexpress.New()
+	r.Use(metrics.Middleware)
+	r.Get("/users", http.NotFoundHandler())
+
+	for _, method := range []string{http.MethodGet, http.MethodPost} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(method, "/nope", http.NoBody))
+	}
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/users", http.NoBody))
+
+	rec := httptest.NewRecorder()
+	metrics.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody))
+
+	for _, want := range []string{
+		`http_requests_total{method="GET",route="unmatched",status="4xx"} 1`,
+		`http_requests_total{method="POST",route="unmatched",status="4xx"} 2`,
+	} {
+		if !strings.Contains(rec.Body.String(), want+"\n") {
+			t.Errorf("metrics do not contain %q:\n%s", want, rec.Body.String())
+		}
+	}
+}
@@ -2,64 +2,94 @@ package goexpress
 
 import (
 	"log/slog"
-	"net"
 	"net/http"
 	"runtime/debug"
-	"strings"
+	"slices"
 )
 
-// LogRequest logs each incoming HTTP request including the method, URL, protocol,
-// status code, status text, and duration of the request.
-func LogRequest(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		slog.Info("New Request",
-			"user_agent", r.UserAgent(),
-			"remote_address", getIPAddress(r),
-			"method", r.Method,
-			"path", r.URL.Path,
-			"proto", r.Proto,
-			slog.Any("headers", r.Header),
-		)
-		next.ServeHTTP(w, r)
-	})
+// RecovererOptions configures the middleware returned by NewRecoverer.
+type RecovererOptions struct {
+	// Logger receives the panic reports. When nil, the logger of the request set by
+	// ContextLogger is used, or else the default slog logger.
+	Logger *slog.Logger
+
+	// Responder writes the response sent to the client after a panic.
+	// A plain-text 500 (Internal Server Error) response is written when nil.
+	// It is not called when the handler had already started writing the response.
+	Responder func(w http.ResponseWriter, r *http.Request, err any)
+
+	// Reporter, if set, receives the panic value, the stack trace and the request,
+	// e.g. to forward them to an error tracker.
+	Reporter func(r *http.Request, err any, stack []byte)
 }
 
+// recoverPanic is the middleware behind RecoverPanic.
+var recoverPanic = NewRecoverer(RecovererOptions{})
+
 // RecoverPanic is middleware that recovers from panics that occur during the execution
-// of the handler. If a panic is detected, it logs the error and stack trace, and returns
-// a 500 (Internal Server Error) response to the client.
+// of the handler. If a panic is detected, it logs the error and stack trace, along with the
+// ID set by RequestID, and returns a 500 (Internal Server Error) response to the client.
 func RecoverPanic(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		defer func() {
-			if err := recover(); err != nil {
-				slog.Error("panic occurred",
-					"reason", err,
-					"stack_trace", string(debug.Stack()),
-				)
-				const status = http.StatusInternalServerError
-				http.Error(w, http.StatusText(status), status)
-			}
-		}()
-		next.ServeHTTP(w, r)
-	})
+	return recoverPanic(next)
 }
 
-// getIPAddress extracts the client's IP address from the request.
-func getIPAddress(r *http.Request) string {
-	if ip := r.Header.Get("X-Real-IP"); ip != "" {
-		return ip
+// NewRecoverer returns a middleware that recovers from panics in the handler, logs and reports
+// them, and responds to the client according to the given options.
+//
+// A panic with http.ErrAbortHandler is propagated so that net/http aborts the response.
+// When the handler had already started writing the response, nothing more is written.
+func NewRecoverer(opts RecovererOptions) Middleware {
+	respond := opts.Responder
+	if respond == nil {
+		respond = func(w http.ResponseWriter, _ *http.Request, _ any) {
+			const status = http.StatusInternalServerError
+			http.Error(w, http.StatusText(status), status)
+		}
 	}
 
-	if forwardedFor := r.Header.Values("X-Forwarded-For"); len(forwardedFor) > 0 {
-		firstIP := forwardedFor[0]
-		ips := strings.Split(firstIP, ",")
-		if len(ips) > 0 {
-			return strings.TrimSpace(ips[0])
-		}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rw := newResponseWriter(w)
+
+			defer func() {
+				err := recover()
+				if err == nil {
+					return
+				}
+
+				if err == http.ErrAbortHandler { //nolint:errorlint // the panic value is compared, not wrapped
+					panic(err)
+				}
+
+				stack := debug.Stack()
+
+				logger, keys := loggerFor(r, opts.Logger)
+				args := []any{"reason", err, "stack_trace", string(stack)}
+				if id, ok := RequestIDFromContext(r.Context()); ok && !slices.Contains(keys, "request_id") {
+					args = append(args, "request_id", id)
+				}
+				logger.Error("panic occurred", args...)
+
+				if opts.Reporter != nil {
+					opts.Reporter(r, err, stack)
+				}
+
+				if !rw.wroteHeader {
+					respond(rw, r, err)
+				}
+			}()
+
+			next.ServeHTTP(rw, r)
+		})
 	}
+}
 
-	ip, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		return r.RemoteAddr
+// getIPAddress returns the client IP address stored in the request context by RealIP, or
+// else the address of RemoteAddr. The forwarding headers are not used, since any client can
+// set them; see ClientIP for resolving the address behind trusted proxies.
+func getIPAddress(r *http.Request) string {
+	if ip, ok := ClientIPFromContext(r.Context()); ok {
+		return ip
 	}
-	return ip
+	return remoteIP(r)
 }
@@ -1,6 +1,8 @@
 package goexpress_test
 
 import (
+	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -24,3 +26,95 @@ func TestPanicRecovery(t *testing.T) {
 		t.Errorf(errStatusFmt, http.StatusInternalServerError, rec.Code)
 	}
 }
+
+func TestNewRecoverer(t *testing.T) {
+	t.Parallel()
+
+	const (
+		contentType = "application/problem+json"
+		problem     = `{"title":"Internal Server Error"}`
+	)
+
+	var (
+		reportedErr   any
+		reportedStack []byte
+		reportedPath  string
+	)
+
+	recoverer := goexpress.NewRecoverer(goexpress.RecovererOptions{
+		Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Responder: func(w http.ResponseWriter, _ *http.Request, _ any) {
+			w.Header().Set("Content-Type", contentType)
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(problem))
+		},
+		Reporter: func(r *http.Request, err any, stack []byte) {
+			reportedErr = err
+			reportedStack = stack
+			reportedPath = r.URL.Path
+		},
+	})
+
+	handler := http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		panic("test panic")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/panic", http.NoBody)
+	rec := httptest.NewRecorder()
+	recoverer(handler).ServeHTTP(rec, req)
+
+	assertStatus(t, rec.Code, http.StatusInternalServerError)
+	assertHeader(t, rec, "Content-Type", contentType)
+	assertBody(t, rec.Body.String(), problem)
+
+	if reportedErr != "test panic" {
+		t.Errorf("reported error = %v, want: %q", reportedErr, "test panic")
+	}
+	if len(reportedStack) == 0 {
+		t.Error("reported stack is empty")
+	}
+	if reportedPath != "/panic" {
+		t.Errorf("reported path = %q, want: %q", reportedPath, "/panic")
+	}
+}
+
+func TestNewRecovererStartedResponse(t *testing.T) {
+	t.Parallel()
+
+	recoverer := goexpress.NewRecoverer(goexpress.RecovererOptions{
+		Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("partial"))
+		panic("test panic")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/panic", http.NoBody)
+	rec := httptest.NewRecorder()
+	recoverer(handler).ServeHTTP(rec, req)
+
+	assertStatus(t, rec.Code, http.StatusAccepted)
+	assertBody(t, rec.Body.String(), "partial")
+}
+
+func TestNewRecovererAbortHandler(t *testing.T) {
+	t.Parallel()
+
+	handler := http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		panic(http.ErrAbortHandler)
+	})
+
+	defer func() {
+		if err := recover(); err != http.ErrAbortHandler { //nolint:errorlint // the panic value is compared, not wrapped
+			t.Errorf("recover() = %v, want: %v", err, http.ErrAbortHandler)
+		}
+	}()
+
+	req := httptest.NewRequest(http.MethodGet, "/abort", http.NoBody)
+	rec := httptest.NewRecorder()
+	goexpress.RecoverPanic(handler).ServeHTTP(rec, req)
+
+	t.Error("http.ErrAbortHandler was not propagated")
+}
@@ -0,0 +1,111 @@
+package goexpress
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// constraint restricts the values of a path parameter.
+type constraint struct {
+	name  string                  // name of a built-in constraint or the regular expression
+	match func(value string) bool // reports whether the value satisfies the constraint
+}
+
+// Built-in constraints, referenced by name in path patterns such as {id:int}.
+var builtinConstraints = map[string]func(string) bool{
+	"int": func(v string) bool {
+		_, err := strconv.ParseInt(v, 10, 64)
+		return err == nil
+	},
+	"uuid":  regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`).MatchString,
+	"alpha": regexp.MustCompile(`^[a-zA-Z]+$`).MatchString,
+	"alnum": regexp.MustCompile(`^[a-zA-Z0-9]+$`).MatchString,
+}
+
+// newConstraint returns the built-in constraint with the given name, or else a constraint
+// matching the whole value against the expression as a regular expression.
+func newConstraint(expr string) (*constraint, error) {
+	if match, ok := builtinConstraints[expr]; ok {
+		return &constraint{name: expr, match: match}, nil
+	}
+
+	re, err := regexp.Compile(`^(?:` + expr + `)$`)
+	if err != nil {
+		return nil, fmt.Errorf("invalid constraint: %w", err)
+	}
+	return &constraint{name: expr, match: re.MatchString}, nil
+}
+
+// ParamInt returns the value of the named path parameter of the request as an int.
+//
+// Parameters registered with the int constraint, e.g. {id:int}, are guaranteed to be
+// valid integers by the router. An error is returned if the parameter is missing, has
+// another constraint, or is not a valid int.
+func ParamInt(r *http.Request, name string) (int, error) {
+	n, err := paramInt(r, name, strconv.IntSize)
+	return int(n), err
+}
+
+// ParamInt64 returns the value of the named path parameter of the request as an int64.
+// See ParamInt for details.
+func ParamInt64(r *http.Request, name string) (int64, error) {
+	return paramInt(r, name, 64)
+}
+
+// paramInt parses the named path parameter of the request as an integer of the given bit size.
+func paramInt(r *http.Request, name string, bitSize int) (int64, error) {
+	if rt := routeFromRequest(r); rt != nil {
+		if c, ok := rt.constraints[name]; ok && c.name != "int" {
+			return 0, fmt.Errorf("goexpress: parameter %q has constraint %q, not int", name, c.name)
+		}
+	}
+
+	value := r.PathValue(name)
+	if value == "" {
+		return 0, fmt.Errorf("%w: %q", ErrMissingParam, name)
+	}
+
+	n, err := strconv.ParseInt(value, 10, bitSize)
+	if err != nil {
+		return 0, fmt.Errorf("goexpress: parameter %q: %w", name, err)
+	}
+	return n, nil
+}
+
+// satisfies reports whether the parameters of the escaped request path, matched by the pattern
+// of the route, satisfy the constraints of the route. It is used when the path values of the
+// request are not set, e.g. for the routes matched by the probes of allowedMethods.
+func (r *route) satisfies(escapedPath string) bool {
+	if len(r.constraints) == 0 {
+		return true
+	}
+
+	segments := strings.Split(escapedPath, "/")
+	for i, segment := range strings.Split(r.path, "/") {
+		if i >= len(segments) || !strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") {
+			continue
+		}
+
+		name, multi := strings.CutSuffix(segment[1:len(segment)-1], "...")
+		c, ok := r.constraints[name]
+		if !ok {
+			continue
+		}
+
+		value := segments[i]
+		if multi {
+			value = strings.Join(segments[i:], "/")
+		}
+		if unescaped, err := url.PathUnescape(value); err == nil {
+			value = unescaped
+		}
+		if !c.match(value) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,250 @@
+package goexpress_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/ferdiebergado/goexpress"
+)
+
+func TestConstrainedParams(t *testing.T) {
+	t.Parallel()
+
+	echo := func(name string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(r.PathValue(name)))
+		})
+	}
+
+	r := goexpress.New()
+	r.Get("/users/{id:int}", echo("id"))
+	r.Get("/users/{name}/profile", echo("name"))
+	r.Get("/posts/{slug:[a-z-]+}", echo("slug"))
+	r.Get("/status/:code:[0-9]{3}", echo("code"))
+	r.Get("/orders/{uuid:uuid}", echo("uuid"))
+	r.Group("/api", func(api *goexpress.Router) {
+		api.Get("/tags/{tag:alpha}", echo("tag"))
+		api.NotFound(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("api not found"))
+		}))
+	})
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "int",
+			path:       "/users/42",
+			wantStatus: http.StatusOK,
+			wantBody:   "42",
+		},
+		{
+			name:       "negative int",
+			path:       "/users/-7",
+			wantStatus: http.StatusOK,
+			wantBody:   "-7",
+		},
+		{
+			name:       "not an int",
+			path:       "/users/abc",
+			wantStatus: http.StatusNotFound,
+			wantBody:   "404 page not found",
+		},
+		{
+			name:       "regular expression",
+			path:       "/posts/hello-world",
+			wantStatus: http.StatusOK,
+			wantBody:   "hello-world",
+		},
+		{
+			name:       "regular expression partial match",
+			path:       "/posts/Hello-world",
+			wantStatus: http.StatusNotFound,
+			wantBody:   "404 page not found",
+		},
+		{
+			name:       "express parameter with repetition",
+			path:       "/status/404",
+			wantStatus: http.StatusOK,
+			wantBody:   "404",
+		},
+		{
+			name:       "express parameter with repetition mismatch",
+			path:       "/status/4040",
+			wantStatus: http.StatusNotFound,
+			wantBody:   "404 page not found",
+		},
+		{
+			name:       "uuid",
+			path:       "/orders/0b6f1c1e-8f4a-4c3e-9d2b-7a1e5f3c9d10",
+			wantStatus: http.StatusOK,
+			wantBody:   "0b6f1c1e-8f4a-4c3e-9d2b-7a1e5f3c9d10",
+		},
+		{
+			name:       "not a uuid",
+			path:       "/orders/42",
+			wantStatus: http.StatusNotFound,
+			wantBody:   "404 page not found",
+		},
+		{
+			name:       "group not found handler",
+			path:       "/api/tags/go1",
+			wantStatus: http.StatusNotFound,
+			wantBody:   "api not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, http.NoBody)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assertStatus(t, rec.Code, tt.wantStatus)
+			assertBody(t, rec.Body.String(), tt.wantBody)
+		})
+	}
+
+	if got, want := r.Routes()[0].Pattern, "/users/{id}"; got != want {
+		t.Errorf("r.Routes()[0].Pattern = %q, want: %q", got, want)
+	}
+}
+
+func TestConstrainedParamsMethodNotAllowed(t *testing.T) {
+	t.Parallel()
+
+	r := goexpress.New()
+	r.Get("/users/{id:int}", http.NotFoundHandler())
+	r.Get("/files/{path...:[^0-9]+}", http.NotFoundHandler())
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantAllow  string
+	}{
+		{
+			name:       "satisfied constraint",
+			path:       "/users/42",
+			wantStatus: http.StatusMethodNotAllowed,
+			wantAllow:  "GET, HEAD",
+		},
+		{
+			name:       "unsatisfied constraint",
+			path:       "/users/abc",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "satisfied remaining segments constraint",
+			path:       "/files/docs/report",
+			wantStatus: http.StatusMethodNotAllowed,
+			wantAllow:  "GET, HEAD",
+		},
+		{
+			name:       "unsatisfied remaining segments constraint",
+			path:       "/files/docs/2024",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, http.NoBody)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assertStatus(t, rec.Code, tt.wantStatus)
+			assertHeader(t, rec, "Allow", tt.wantAllow)
+		})
+	}
+}
+
+func TestParamInt(t *testing.T) {
+	t.Parallel()
+
+	var (
+		gotInt int
+		gotErr error
+	)
+	handler := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		gotInt, gotErr = goexpress.ParamInt(r, "id")
+	})
+
+	tests := []struct {
+		name    string
+		pattern string
+		path    string
+		want    int
+		wantErr error
+	}{
+		{
+			name:    "int constraint",
+			pattern: "/users/{id:int}",
+			path:    "/users/42",
+			want:    42,
+		},
+		{
+			name:    "unconstrained",
+			pattern: "/users/{id}",
+			path:    "/users/7",
+			want:    7,
+		},
+		{
+			name:    "unconstrained not an int",
+			pattern: "/users/{id}",
+			path:    "/users/abc",
+			wantErr: strconv.ErrSyntax,
+		},
+		{
+			name:    "missing parameter",
+			pattern: "/users/{name}",
+			path:    "/users/abc",
+			wantErr: goexpress.ErrMissingParam,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := goexpress.New()
+			r.Get(tt.pattern, handler)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, http.NoBody)
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			if !errors.Is(gotErr, tt.wantErr) {
+				t.Fatalf("ParamInt() error = %v, want: %v", gotErr, tt.wantErr)
+			}
+			if gotInt != tt.want {
+				t.Errorf("ParamInt() = %d, want: %d", gotInt, tt.want)
+			}
+		})
+	}
+}
+
+func TestParamIntOtherConstraint(t *testing.T) {
+	t.Parallel()
+
+	var gotErr error
+	r := goexpress.New()
+	r.Get("/codes/{id:[0-9a-f]+}", http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		_, gotErr = goexpress.ParamInt64(r, "id")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/codes/10", http.NoBody)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	if gotErr == nil {
+		t.Error("ParamInt64() error = nil, want: error for a non-int constraint")
+	}
+}
@@ -0,0 +1,124 @@
+package goexpress
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// ErrInvalidPattern is wrapped by the errors that route registration panics with when the
+// path pattern is invalid or conflicts with a registered pattern.
+var ErrInvalidPattern = errors.New("goexpress: invalid route pattern")
+
+// translatePath translates the Express-style parameters of the path to http.ServeMux wildcards
+// and validates the wildcards. A :name segment becomes {name}, and a trailing *name segment
+// becomes {name...}. The constraints of the parameters, e.g. {id:int}, are removed from the
+// path and returned by parameter name.
+func translatePath(p string) (string, map[string]*constraint, error) {
+	segments := strings.Split(p, "/")
+	names := make([]string, 0, len(segments))
+	var constraints map[string]*constraint
+
+	for i, segment := range segments {
+		last := i == len(segments)-1
+
+		switch {
+		case strings.HasPrefix(segment, ":"):
+			segment = "{" + segment[1:] + "}"
+		case strings.HasPrefix(segment, "*"):
+			if !last {
+				return "", nil, patternError(p, "catch-all parameter %q must be the last segment", segment)
+			}
+			segment = "{" + segment[1:] + "...}"
+		case !strings.ContainsAny(segment, "{}"):
+			continue
+		}
+
+		w, err := parseWildcard(segment, last)
+		if err != nil {
+			return "", nil, patternError(p, "%v", err)
+		}
+		if w.name != "" {
+			if slices.Contains(names, w.name) {
+				return "", nil, patternError(p, "duplicate parameter %q", w.name)
+			}
+			names = append(names, w.name)
+		}
+		if w.constraint != nil {
+			if constraints == nil {
+				constraints = make(map[string]*constraint)
+			}
+			constraints[w.name] = w.constraint
+		}
+
+		segments[i] = w.segment
+	}
+
+	return strings.Join(segments, "/"), constraints, nil
+}
+
+// wildcard is a parsed wildcard segment of a path pattern.
+type wildcard struct {
+	name       string      // parameter name, empty for {$}
+	segment    string      // http.ServeMux wildcard segment, without the constraint
+	constraint *constraint // constraint of the parameter, nil if unconstrained
+}
+
+// parseWildcard validates the wildcard segment, e.g. {id}, {path...} or {id:int}, and parses it.
+func parseWildcard(segment string, last bool) (wildcard, error) {
+	if len(segment) < 2 || segment[0] != '{' || segment[len(segment)-1] != '}' {
+		return wildcard{}, fmt.Errorf("parameter %q must be a whole path segment", segment)
+	}
+
+	name, expr, constrained := strings.Cut(segment[1:len(segment)-1], ":")
+	if name == "$" && !constrained {
+		if !last {
+			return wildcard{}, errors.New("{$} must be at the end of the path")
+		}
+		return wildcard{segment: segment}, nil
+	}
+
+	name, multi := strings.CutSuffix(name, "...")
+	if multi && !last {
+		return wildcard{}, fmt.Errorf("catch-all parameter %q must be the last segment", segment)
+	}
+	if !isIdentifier(name) {
+		return wildcard{}, fmt.Errorf("parameter name %q is not a valid identifier", name)
+	}
+
+	w := wildcard{name: name, segment: "{" + name + "}"}
+	if multi {
+		w.segment = "{" + name + "...}"
+	}
+
+	if constrained {
+		c, err := newConstraint(expr)
+		if err != nil {
+			return wildcard{}, fmt.Errorf("parameter %q: %w", name, err)
+		}
+		w.constraint = c
+	}
+
+	return w, nil
+}
+
+// isIdentifier reports whether s is a valid parameter name, following the rules of http.ServeMux.
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i, c := range s {
+		if !unicode.IsLetter(c) && c != '_' && (i == 0 || !unicode.IsDigit(c)) {
+			return false
+		}
+	}
+	return true
+}
+
+// patternError returns an error wrapping ErrInvalidPattern for the path pattern.
+func patternError(p, format string, args ...any) error {
+	return fmt.Errorf("%w %q: %s", ErrInvalidPattern, p, fmt.Sprintf(format, args...))
+}
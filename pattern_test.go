@@ -0,0 +1,154 @@
+package goexpress_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ferdiebergado/goexpress"
+)
+
+func TestExpressParams(t *testing.T) {
+	t.Parallel()
+
+	r := goexpress.New()
+	r.Get("/users/:id", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("user " + r.PathValue("id")))
+	})).Name("user")
+	r.Group("/repos/:owner", func(repos *goexpress.Router) {
+		repos.Get("/files/*path", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(r.PathValue("owner") + ":" + r.PathValue("path")))
+		}))
+	})
+	r.Post("/v1/items:batchGet", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("batch"))
+	}))
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		wantBody string
+	}{
+		{
+			name:     "named parameter",
+			method:   http.MethodGet,
+			path:     "/users/42",
+			wantBody: "user 42",
+		},
+		{
+			name:     "group parameter and catch-all",
+			method:   http.MethodGet,
+			path:     "/repos/acme/files/docs/readme.md",
+			wantBody: "acme:docs/readme.md",
+		},
+		{
+			name:     "literal colon",
+			method:   http.MethodPost,
+			path:     "/v1/items:batchGet",
+			wantBody: "batch",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(tt.method, tt.path, http.NoBody)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assertStatus(t, rec.Code, http.StatusOK)
+			assertBody(t, rec.Body.String(), tt.wantBody)
+		})
+	}
+
+	if got, want := r.Routes()[0].Pattern, "/users/{id}"; got != want {
+		t.Errorf("r.Routes()[0].Pattern = %q, want: %q", got, want)
+	}
+}
+
+func TestInvalidPattern(t *testing.T) {
+	t.Parallel()
+
+	handler := http.NotFoundHandler()
+
+	tests := []struct {
+		name  string
+		setup func(*goexpress.Router)
+	}{
+		{
+			name: "catch-all before the last segment",
+			setup: func(r *goexpress.Router) {
+				r.Get("/files/*path/raw", handler)
+			},
+		},
+		{
+			name: "remaining segments wildcard before the last segment",
+			setup: func(r *goexpress.Router) {
+				r.Get("/files/{path...}/raw", handler)
+			},
+		},
+		{
+			name: "duplicate parameter",
+			setup: func(r *goexpress.Router) {
+				r.Group("/users/:id", func(users *goexpress.Router) {
+					users.Get("/posts/{id}", handler)
+				})
+			},
+		},
+		{
+			name: "empty parameter name",
+			setup: func(r *goexpress.Router) {
+				r.Get("/users/:", handler)
+			},
+		},
+		{
+			name: "invalid parameter name",
+			setup: func(r *goexpress.Router) {
+				r.Get("/users/:user-id", handler)
+			},
+		},
+		{
+			name: "partial segment wildcard",
+			setup: func(r *goexpress.Router) {
+				r.Get("/files/{name}.txt", handler)
+			},
+		},
+		{
+			name: "invalid constraint",
+			setup: func(r *goexpress.Router) {
+				r.Get("/posts/{slug:[a-z}", handler)
+			},
+		},
+		{
+			name: "constraint on {$}",
+			setup: func(r *goexpress.Router) {
+				r.Get("/posts/{$:int}", handler)
+			},
+		},
+		{
+			name: "conflicting patterns",
+			setup: func(r *goexpress.Router) {
+				r.Get("/users/:id", handler)
+				r.Get("/users/{name}", handler)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			defer func() {
+				err, ok := recover().(error)
+				if !ok || !errors.Is(err, goexpress.ErrInvalidPattern) {
+					t.Errorf("recover() = %v, want: error wrapping %v", err, goexpress.ErrInvalidPattern)
+				}
+			}()
+
+			tt.setup(goexpress.New())
+		})
+	}
+}
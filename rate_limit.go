@@ -0,0 +1,303 @@
+package goexpress
+
+import (
+	"context"
+	"hash/maphash"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimitAlgorithm is the algorithm used by RateLimit to limit the request rate.
+type RateLimitAlgorithm int
+
+// Rate limiting algorithms.
+const (
+	// TokenBucket allows bursts of up to Limit requests, refilled at Limit requests per Window.
+	TokenBucket RateLimitAlgorithm = iota
+
+	// SlidingWindow allows Limit requests in any period of Window, estimated from the counts of
+	// the current and the previous fixed windows.
+	SlidingWindow
+)
+
+// RateLimitPolicy is the limit applied to the requests with the same key.
+type RateLimitPolicy struct {
+	Algorithm RateLimitAlgorithm // rate limiting algorithm
+	Limit     int                // number of requests allowed per window
+	Window    time.Duration      // duration of the window
+}
+
+// RateLimitResult is the outcome of a request against its rate limit.
+type RateLimitResult struct {
+	Allowed    bool          // whether the request is allowed
+	Limit      int           // number of requests allowed per window
+	Remaining  int           // number of requests still allowed now
+	Reset      time.Duration // time until the quota is fully available again
+	RetryAfter time.Duration // time until the next request is allowed, zero if allowed
+}
+
+// RateLimitStore stores the state of the rate limits, e.g. in memory or in an external
+// backend shared by several instances. Implementations must be safe for concurrent use.
+type RateLimitStore interface {
+	// Allow records a request with the key at the given time against the policy, atomically,
+	// and returns the outcome.
+	Allow(ctx context.Context, key string, policy RateLimitPolicy, now time.Time) (RateLimitResult, error)
+}
+
+// RateLimitOptions configures the middleware returned by RateLimit.
+type RateLimitOptions struct {
+	// Algorithm is the rate limiting algorithm, TokenBucket by default.
+	Algorithm RateLimitAlgorithm
+
+	// Limit is the number of requests allowed per Window. It must be positive.
+	Limit int
+
+	// Window is the period of the limit. It must be positive.
+	Window time.Duration
+
+	// KeyFunc returns the key of the request, whose requests share the same limit.
+	// RateLimitByIP is used when nil.
+	KeyFunc func(r *http.Request) string
+
+	// Prefix is prepended to the keys, so that several limiters can share a store.
+	Prefix string
+
+	// Store stores the state of the limits. A new MemoryRateLimitStore is used when nil.
+	Store RateLimitStore
+
+	// LimitedHandler writes the response to the requests over the limit, after the rate limit
+	// headers are set. A plain-text 429 (Too Many Requests) response is written when nil.
+	LimitedHandler http.Handler
+}
+
+// RateLimit returns a middleware that limits the rate of the requests with the same key
+// according to the given options. It can be registered with Use, or for a single route:
+//
+//	router.Post("/login", loginHandler, goexpress.RateLimit(goexpress.RateLimitOptions{
+//		Limit:  5,
+//		Window: time.Minute,
+//	}))
+//
+// The responses carry the RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset and
+// RateLimit-Policy headers, and the responses to the requests over the limit carry a
+// Retry-After header. When the store fails, the error is logged and the request is allowed.
+// It panics if the limit or the window is not positive.
+func RateLimit(opts RateLimitOptions) Middleware {
+	if opts.Limit <= 0 || opts.Window <= 0 {
+		panic("goexpress: rate limit and window must be positive")
+	}
+
+	policy := RateLimitPolicy{Algorithm: opts.Algorithm, Limit: opts.Limit, Window: opts.Window}
+	policyHeader := strconv.Itoa(opts.Limit) + ";w=" + strconv.Itoa(ceilSeconds(opts.Window))
+
+	keyFunc := opts.KeyFunc
+	if keyFunc == nil {
+		keyFunc = RateLimitByIP
+	}
+
+	store := opts.Store
+	if store == nil {
+		store = NewMemoryRateLimitStore()
+	}
+
+	limited := opts.LimitedHandler
+	if limited == nil {
+		limited = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			const status = http.StatusTooManyRequests
+			http.Error(w, http.StatusText(status), status)
+		})
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			res, err := store.Allow(r.Context(), opts.Prefix+keyFunc(r), policy, time.Now())
+			if err != nil {
+				Logger(r).Error("rate limit store failed", "error", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h := w.Header()
+			h.Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+			h.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(res.Reset)))
+			h.Set("RateLimit-Policy", policyHeader)
+
+			if !res.Allowed {
+				h.Set("Retry-After", strconv.Itoa(ceilSeconds(res.RetryAfter)))
+				limited.ServeHTTP(w, r)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RateLimitByIP returns the client IP address of the request as rate limit key, as resolved
+// by RealIP or else the address of the connection.
+func RateLimitByIP(r *http.Request) string {
+	return getIPAddress(r)
+}
+
+// RateLimitByHeader returns a key function that uses the value of the header as rate limit key,
+// e.g. an API key. The requests without the header are keyed by client IP address.
+func RateLimitByHeader(name string) func(r *http.Request) string {
+	return func(r *http.Request) string {
+		if v := r.Header.Get(name); v != "" {
+			return "header:" + v
+		}
+		return "ip:" + getIPAddress(r)
+	}
+}
+
+// ceilSeconds returns the duration in whole seconds, rounded up.
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// Parameters of the memory rate limit store.
+const (
+	rateLimitShards = 64          // number of shards, each with its own lock
+	sweepInterval   = time.Minute // minimum interval between the removals of expired entries of a shard
+)
+
+// MemoryRateLimitStore is a RateLimitStore keeping the rate limits in memory, sharded by key
+// to reduce lock contention. Expired entries are removed lazily while the store is in use.
+type MemoryRateLimitStore struct {
+	seed   maphash.Seed
+	shards [rateLimitShards]rateLimitShard
+}
+
+// rateLimitShard holds the rate limits of a subset of the keys.
+type rateLimitShard struct {
+	mu        sync.Mutex
+	entries   map[string]*rateLimitEntry
+	lastSweep time.Time
+}
+
+// rateLimitEntry is the state of the rate limit of a key.
+type rateLimitEntry struct {
+	expires time.Time // time after which the entry is equivalent to a new one
+
+	// TokenBucket state.
+	tokens float64   // available tokens
+	last   time.Time // time of the last refill
+
+	// SlidingWindow state.
+	start    time.Time // start of the current window
+	current  int       // number of requests in the current window
+	previous int       // number of requests in the previous window
+}
+
+// NewMemoryRateLimitStore creates an empty in-memory rate limit store.
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	s := &MemoryRateLimitStore{seed: maphash.MakeSeed()}
+	for i := range s.shards {
+		s.shards[i].entries = make(map[string]*rateLimitEntry)
+	}
+	return s
+}
+
+// Allow records a request with the key against the policy and returns the outcome.
+func (s *MemoryRateLimitStore) Allow(_ context.Context, key string, policy RateLimitPolicy, now time.Time) (RateLimitResult, error) {
+	shard := &s.shards[maphash.String(s.seed, key)%rateLimitShards]
+
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	if now.Sub(shard.lastSweep) >= sweepInterval {
+		for k, e := range shard.entries {
+			if !now.Before(e.expires) {
+				delete(shard.entries, k)
+			}
+		}
+		shard.lastSweep = now
+	}
+
+	e, ok := shard.entries[key]
+	if !ok || !now.Before(e.expires) {
+		e = &rateLimitEntry{tokens: float64(policy.Limit), last: now, start: now}
+		shard.entries[key] = e
+	}
+
+	if policy.Algorithm == SlidingWindow {
+		return e.slidingWindow(policy, now), nil
+	}
+	return e.tokenBucket(policy, now), nil
+}
+
+// tokenBucket applies the token bucket algorithm to a request at the given time.
+func (e *rateLimitEntry) tokenBucket(policy RateLimitPolicy, now time.Time) RateLimitResult {
+	capacity := float64(policy.Limit)
+	rate := capacity / policy.Window.Seconds() // tokens per second
+
+	e.tokens = math.Min(capacity, e.tokens+now.Sub(e.last).Seconds()*rate)
+	e.last = now
+
+	res := RateLimitResult{Limit: policy.Limit}
+	if e.tokens >= 1 {
+		e.tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = secondsDuration((1 - e.tokens) / rate)
+	}
+
+	res.Remaining = int(e.tokens)
+	res.Reset = secondsDuration((capacity - e.tokens) / rate)
+	e.expires = now.Add(res.Reset)
+	return res
+}
+
+// slidingWindow applies the sliding window algorithm to a request at the given time.
+func (e *rateLimitEntry) slidingWindow(policy RateLimitPolicy, now time.Time) RateLimitResult {
+	window := policy.Window
+	if elapsed := now.Sub(e.start); elapsed >= window {
+		// Move to the window containing now; the previous count only carries over to the next window.
+		e.previous = 0
+		if elapsed < 2*window {
+			e.previous = e.current
+		}
+		e.current = 0
+		e.start = e.start.Add(elapsed.Truncate(window))
+	}
+
+	limit := float64(policy.Limit)
+	elapsed := now.Sub(e.start)
+	weight := 1 - elapsed.Seconds()/window.Seconds() // share of the previous window in the sliding window
+	count := float64(e.previous)*weight + float64(e.current)
+
+	res := RateLimitResult{Limit: policy.Limit, Reset: window - elapsed}
+	if count+1 <= limit {
+		e.current++
+		count++
+		res.Allowed = true
+	} else {
+		res.RetryAfter = e.retryAfter(limit, window, elapsed)
+	}
+
+	res.Remaining = max(0, int(limit-count))
+	e.expires = e.start.Add(2 * window)
+	return res
+}
+
+// retryAfter returns the time until the sliding window count drops enough to allow a request.
+func (e *rateLimitEntry) retryAfter(limit float64, window, elapsed time.Duration) time.Duration {
+	// In the current window: previous*(1-t/window) + current <= limit-1.
+	if float64(e.current) <= limit-1 && e.previous > 0 {
+		t := window.Seconds() * (1 - (limit-1-float64(e.current))/float64(e.previous))
+		return secondsDuration(t) - elapsed
+	}
+
+	// In the next window, where the current count becomes the previous one: current*(1-t/window) <= limit-1.
+	t := window.Seconds() * (1 - (limit-1)/float64(e.current))
+	return window - elapsed + secondsDuration(t)
+}
+
+// secondsDuration converts seconds to a duration.
+func secondsDuration(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
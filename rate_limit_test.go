@@ -0,0 +1,254 @@
+package goexpress_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ferdiebergado/goexpress"
+)
+
+func TestRateLimit(t *testing.T) {
+	t.Parallel()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("ok"))
+	})
+
+	r := goexpress.New()
+	r.Get("/", handler)
+	r.Post("/login", handler, goexpress.RateLimit(goexpress.RateLimitOptions{
+		Limit:  2,
+		Window: time.Minute,
+	}))
+	r.Get("/api", handler, goexpress.RateLimit(goexpress.RateLimitOptions{
+		Algorithm: goexpress.SlidingWindow,
+		Limit:     1,
+		Window:    time.Hour,
+		KeyFunc:   goexpress.RateLimitByHeader("X-Api-Key"),
+	}))
+
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		remoteAddr    string
+		apiKey        string
+		wantStatus    int
+		wantRemaining string
+		wantRetry     string
+	}{
+		{
+			name:       "unlimited route",
+			method:     http.MethodGet,
+			path:       "/",
+			remoteAddr: "192.0.2.1:1234",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:          "first request",
+			method:        http.MethodPost,
+			path:          "/login",
+			remoteAddr:    "192.0.2.1:1234",
+			wantStatus:    http.StatusOK,
+			wantRemaining: "1",
+		},
+		{
+			name:          "last allowed request",
+			method:        http.MethodPost,
+			path:          "/login",
+			remoteAddr:    "192.0.2.1:5678",
+			wantStatus:    http.StatusOK,
+			wantRemaining: "0",
+		},
+		{
+			name:          "over the limit",
+			method:        http.MethodPost,
+			path:          "/login",
+			remoteAddr:    "192.0.2.1:1234",
+			wantStatus:    http.StatusTooManyRequests,
+			wantRemaining: "0",
+			wantRetry:     "30",
+		},
+		{
+			name:          "other client",
+			method:        http.MethodPost,
+			path:          "/login",
+			remoteAddr:    "192.0.2.2:1234",
+			wantStatus:    http.StatusOK,
+			wantRemaining: "1",
+		},
+		{
+			name:          "api key",
+			method:        http.MethodGet,
+			path:          "/api",
+			remoteAddr:    "192.0.2.1:1234",
+			apiKey:        "key-1",
+			wantStatus:    http.StatusOK,
+			wantRemaining: "0",
+		},
+		{
+			// The request of the previous window keeps counting until the end of the next window.
+			name:          "api key over the limit from another address",
+			method:        http.MethodGet,
+			path:          "/api",
+			remoteAddr:    "192.0.2.2:1234",
+			apiKey:        "key-1",
+			wantStatus:    http.StatusTooManyRequests,
+			wantRemaining: "0",
+			wantRetry:     "7200",
+		},
+		{
+			name:          "other api key",
+			method:        http.MethodGet,
+			path:          "/api",
+			remoteAddr:    "192.0.2.1:1234",
+			apiKey:        "key-2",
+			wantStatus:    http.StatusOK,
+			wantRemaining: "0",
+		},
+	}
+
+	// The cases run in order, since each request consumes the quota of the next ones.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, http.NoBody)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.apiKey != "" {
+				req.Header.Set("X-Api-Key", tt.apiKey)
+			}
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assertStatus(t, rec.Code, tt.wantStatus)
+			assertHeader(t, rec, "RateLimit-Remaining", tt.wantRemaining)
+			assertHeader(t, rec, "Retry-After", tt.wantRetry)
+		})
+	}
+}
+
+func TestRateLimitHeaders(t *testing.T) {
+	t.Parallel()
+
+	mw := goexpress.RateLimit(goexpress.RateLimitOptions{
+		Limit:  10,
+		Window: time.Minute,
+		LimitedHandler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}),
+	})
+	handler := mw(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+
+	assertStatus(t, rec.Code, http.StatusOK)
+	assertHeader(t, rec, "RateLimit-Limit", "10")
+	assertHeader(t, rec, "RateLimit-Remaining", "9")
+	assertHeader(t, rec, "RateLimit-Reset", "6")
+	assertHeader(t, rec, "RateLimit-Policy", "10;w=60")
+}
+
+type failingStore struct{}
+
+func (failingStore) Allow(context.Context, string, goexpress.RateLimitPolicy, time.Time) (goexpress.RateLimitResult, error) {
+	return goexpress.RateLimitResult{}, errors.New("store unavailable")
+}
+
+func TestRateLimitStoreError(t *testing.T) {
+	t.Parallel()
+
+	r := goexpress.New()
+	r.Use(goexpress.ContextLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	r.Get("/", http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {}), goexpress.RateLimit(goexpress.RateLimitOptions{
+		Limit:  1,
+		Window: time.Second,
+		Store:  failingStore{},
+	}))
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+
+	assertStatus(t, rec.Code, http.StatusOK)
+	assertHeader(t, rec, "RateLimit-Limit", "")
+}
+
+func TestRateLimitInvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("RateLimit() did not panic, want: panic for a zero window")
+		}
+	}()
+
+	goexpress.RateLimit(goexpress.RateLimitOptions{Limit: 1})
+}
+
+func TestMemoryRateLimitStore(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type step struct {
+		at          time.Duration // time of the request since start
+		wantAllowed bool
+		wantRemain  int
+		wantRetry   time.Duration
+	}
+
+	tests := []struct {
+		name   string
+		policy goexpress.RateLimitPolicy
+		steps  []step
+	}{
+		{
+			name:   "token bucket",
+			policy: goexpress.RateLimitPolicy{Algorithm: goexpress.TokenBucket, Limit: 2, Window: 10 * time.Second},
+			steps: []step{
+				{at: 0, wantAllowed: true, wantRemain: 1},
+				{at: 0, wantAllowed: true, wantRemain: 0},
+				{at: time.Second, wantAllowed: false, wantRemain: 0, wantRetry: 4 * time.Second},
+				{at: 5 * time.Second, wantAllowed: true, wantRemain: 0},
+				{at: time.Hour, wantAllowed: true, wantRemain: 1},
+			},
+		},
+		{
+			name:   "sliding window",
+			policy: goexpress.RateLimitPolicy{Algorithm: goexpress.SlidingWindow, Limit: 2, Window: 10 * time.Second},
+			steps: []step{
+				{at: 0, wantAllowed: true, wantRemain: 1},
+				{at: 5 * time.Second, wantAllowed: true, wantRemain: 0},
+				{at: 9 * time.Second, wantAllowed: false, wantRemain: 0, wantRetry: 6 * time.Second},
+				// The previous window counts for 2 * 0.5 requests.
+				{at: 15 * time.Second, wantAllowed: true, wantRemain: 0},
+				{at: 16 * time.Second, wantAllowed: false, wantRemain: 0, wantRetry: 4 * time.Second},
+				{at: time.Hour, wantAllowed: true, wantRemain: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := goexpress.NewMemoryRateLimitStore()
+			for i, s := range tt.steps {
+				res, err := store.Allow(context.Background(), "key", tt.policy, start.Add(s.at))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if res.Allowed != s.wantAllowed || res.Remaining != s.wantRemain || res.RetryAfter != s.wantRetry {
+					t.Errorf("step %d: Allow() = %+v, want: allowed %t, remaining %d, retry after %v",
+						i, res, s.wantAllowed, s.wantRemain, s.wantRetry)
+				}
+			}
+		})
+	}
+}
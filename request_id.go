@@ -0,0 +1,134 @@
+package goexpress
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"net/http"
+	"time"
+)
+
+// DefaultRequestIDHeader is the header carrying the request ID when RequestIDOptions.Header is empty.
+const DefaultRequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength is the maximum length of an incoming request ID accepted by default.
+const maxRequestIDLength = 128
+
+// RequestIDOptions configures the middleware returned by NewRequestID.
+type RequestIDOptions struct {
+	// Header is the request and response header carrying the request ID.
+	// DefaultRequestIDHeader is used when empty.
+	Header string
+
+	// Validate reports whether an incoming request ID is accepted. A new ID is generated for
+	// the requests whose ID is rejected. When nil, IDs of up to 128 letters, digits, and
+	// '-', '_', '.' or ':' characters are accepted.
+	Validate func(id string) bool
+
+	// Generator returns a new request ID. RandomRequestID is used when nil.
+	Generator func() string
+}
+
+// requestID is the middleware behind RequestID.
+var requestID = NewRequestID(RequestIDOptions{})
+
+// RequestID is middleware that propagates the X-Request-ID header of the request, or generates
+// a random ID if it is missing or invalid. The ID is set on the response and stored in the request
+// context, where it is available with RequestIDFromContext and logged by LogRequest and RecoverPanic.
+func RequestID(next http.Handler) http.Handler {
+	return requestID(next)
+}
+
+// NewRequestID returns a middleware that propagates or generates the request ID according to
+// the given options. See RequestID for details.
+func NewRequestID(opts RequestIDOptions) Middleware {
+	header := opts.Header
+	if header == "" {
+		header = DefaultRequestIDHeader
+	}
+
+	validate := opts.Validate
+	if validate == nil {
+		validate = validRequestID
+	}
+
+	generate := opts.Generator
+	if generate == nil {
+		generate = RandomRequestID
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(header)
+			if id == "" || !validate(id) {
+				id = generate()
+			}
+
+			w.Header().Set(header, id)
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIDKey, id)))
+		})
+	}
+}
+
+// RequestIDFromContext returns the request ID stored in the context by RequestID.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey).(string)
+	return id, ok
+}
+
+// validRequestID reports whether the incoming request ID is accepted by default.
+func validRequestID(id string) bool {
+	if len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for _, c := range []byte(id) {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// RandomRequestID returns a random version 4 UUID, e.g. 5b8e3c1a-6f2d-4e7b-9a41-0c3d2e1f4a5b.
+func RandomRequestID() string {
+	var b [16]byte
+	rand.Read(b[:]) //nolint:errcheck // crypto/rand.Read never returns an error
+
+	return formatUUID(b, 4)
+}
+
+// TimeOrderedRequestID returns a version 7 UUID, whose first 48 bits are the Unix time in
+// milliseconds, so that IDs generated later sort after earlier ones.
+func TimeOrderedRequestID() string {
+	var b [16]byte
+	rand.Read(b[6:]) //nolint:errcheck // crypto/rand.Read never returns an error
+
+	var ms [8]byte
+	binary.BigEndian.PutUint64(ms[:], uint64(time.Now().UnixMilli()))
+	copy(b[:6], ms[2:])
+
+	return formatUUID(b, 7)
+}
+
+// formatUUID sets the version and the RFC 9562 variant of the UUID and formats it.
+func formatUUID(b [16]byte, version byte) string {
+	b[6] = b[6]&0x0f | version<<4
+	b[8] = b[8]&0x3f | 0x80
+
+	var s [36]byte
+	hex.Encode(s[0:8], b[0:4])
+	s[8] = '-'
+	hex.Encode(s[9:13], b[4:6])
+	s[13] = '-'
+	hex.Encode(s[14:18], b[6:8])
+	s[18] = '-'
+	hex.Encode(s[19:23], b[8:10])
+	s[23] = '-'
+	hex.Encode(s[24:], b[10:])
+	return string(s[:])
+}
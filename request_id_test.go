@@ -0,0 +1,151 @@
+package goexpress_test
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/ferdiebergado/goexpress"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-([47])[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestRequestID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		opts     goexpress.RequestIDOptions
+		header   string
+		incoming string
+		want     string // expected ID, or empty for a generated ID
+	}{
+		{
+			name:     "propagated",
+			header:   goexpress.DefaultRequestIDHeader,
+			incoming: "abc-123",
+			want:     "abc-123",
+		},
+		{
+			name:   "generated",
+			header: goexpress.DefaultRequestIDHeader,
+		},
+		{
+			name:     "invalid characters",
+			header:   goexpress.DefaultRequestIDHeader,
+			incoming: "abc 123\"",
+		},
+		{
+			name:     "too long",
+			header:   goexpress.DefaultRequestIDHeader,
+			incoming: strings.Repeat("a", 129),
+		},
+		{
+			name:     "custom header and validation",
+			opts:     goexpress.RequestIDOptions{Header: "X-Trace-Id", Validate: func(id string) bool { return strings.HasPrefix(id, "t-") }},
+			header:   "X-Trace-Id",
+			incoming: "t-1",
+			want:     "t-1",
+		},
+		{
+			name:     "custom validation rejects",
+			opts:     goexpress.RequestIDOptions{Validate: func(id string) bool { return strings.HasPrefix(id, "t-") }},
+			header:   goexpress.DefaultRequestIDHeader,
+			incoming: "abc",
+		},
+		{
+			name:   "custom generator",
+			opts:   goexpress.RequestIDOptions{Generator: func() string { return "fixed" }},
+			header: goexpress.DefaultRequestIDHeader,
+			want:   "fixed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var fromContext string
+			handler := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				fromContext, _ = goexpress.RequestIDFromContext(r.Context())
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			if tt.incoming != "" {
+				req.Header.Set(tt.header, tt.incoming)
+			}
+			rec := httptest.NewRecorder()
+			goexpress.NewRequestID(tt.opts)(handler).ServeHTTP(rec, req)
+
+			got := rec.Header().Get(tt.header)
+			if fromContext != got {
+				t.Errorf("RequestIDFromContext() = %q, want: %q", fromContext, got)
+			}
+			if tt.want != "" {
+				if got != tt.want {
+					t.Errorf("request ID = %q, want: %q", got, tt.want)
+				}
+				return
+			}
+			if !uuidPattern.MatchString(got) {
+				t.Errorf("request ID = %q, want: a generated UUID", got)
+			}
+		})
+	}
+}
+
+func TestRequestIDGenerators(t *testing.T) {
+	t.Parallel()
+
+	if m := uuidPattern.FindStringSubmatch(goexpress.RandomRequestID()); m == nil || m[1] != "4" {
+		t.Errorf("RandomRequestID() is not a version 4 UUID: %v", m)
+	}
+
+	ids := make([]string, 0, 100)
+	for range 100 {
+		id := goexpress.TimeOrderedRequestID()
+		if m := uuidPattern.FindStringSubmatch(id); m == nil || m[1] != "7" {
+			t.Fatalf("TimeOrderedRequestID() = %q, want: a version 7 UUID", id)
+		}
+		ids = append(ids, id)
+	}
+	// The millisecond timestamp prefix never decreases.
+	for i := 1; i < len(ids); i++ {
+		if ids[i][:13] < ids[i-1][:13] {
+			t.Errorf("TimeOrderedRequestID() = %q after %q, want: non-decreasing timestamps", ids[i], ids[i-1])
+		}
+	}
+}
+
+func TestRequestIDLogging(t *testing.T) {
+	t.Parallel()
+
+	lc := &logCapture{}
+	logger := slog.New(lc)
+
+	r := goexpress.New()
+	r.Use(goexpress.RequestID)
+	r.Use(goexpress.NewRequestLogger(goexpress.RequestLoggerOptions{Logger: logger}))
+	r.Use(goexpress.NewRecoverer(goexpress.RecovererOptions{Logger: logger}))
+	r.Get("/panic", http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		panic("test panic")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/panic", http.NoBody)
+	req.Header.Set(goexpress.DefaultRequestIDHeader, "req-1")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assertStatus(t, rec.Code, http.StatusInternalServerError)
+	if len(lc.entries) != 2 {
+		t.Fatalf("len(entries) = %d, want: 2", len(lc.entries))
+	}
+	for _, entry := range lc.entries {
+		if got := entry["request_id"]; got != "req-1" {
+			t.Errorf("Logged request_id = %v; want %v", got, "req-1")
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package goexpress
+
+import (
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"path"
+	"time"
+)
+
+// redacted replaces the values of redacted headers in the log entries.
+const redacted = "[REDACTED]"
+
+// defaultRedactHeaders lists the headers redacted when RequestLoggerOptions.RedactHeaders is nil.
+var defaultRedactHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization", "Set-Cookie"}
+
+// RequestLoggerOptions configures the middleware returned by NewRequestLogger.
+type RequestLoggerOptions struct {
+	// Logger receives the log entries. When nil, the logger of the request set by
+	// ContextLogger is used, or else the default slog logger.
+	Logger *slog.Logger
+
+	// Fields lists the attributes included in each entry. All attributes are included when empty.
+	// The available attributes are user_agent, remote_address, method, path, proto, headers,
+	// status, status_text, bytes and duration, as well as route for the requests matching a
+	// route and request_id for the requests with an ID set by RequestID.
+	Fields []string
+
+	// AllowHeaders lists the only request headers to log. All headers are logged when empty.
+	AllowHeaders []string
+
+	// RedactHeaders lists the request headers whose values are replaced with "[REDACTED]".
+	// When nil, the Authorization, Cookie, Proxy-Authorization and Set-Cookie headers are redacted.
+	// Use an empty, non-nil slice to disable redaction.
+	RedactHeaders []string
+
+	// SkipPaths lists path.Match patterns of request paths that are not logged, e.g. "/healthz".
+	SkipPaths []string
+
+	// Levels maps a status class (1 to 5) to the level of its entries. Classes without a level
+	// are logged at slog.LevelError for 5xx, slog.LevelWarn for 4xx and slog.LevelInfo otherwise.
+	Levels map[int]slog.Level
+
+	// SampleRate is the fraction of requests to log, between 0 and 1. Zero logs every request.
+	// Server errors (5xx) are always logged.
+	SampleRate float64
+}
+
+// requestLogger holds the resolved configuration of a request logging middleware.
+type requestLogger struct {
+	logger     *slog.Logger
+	fields     map[string]bool
+	allow      map[string]bool
+	redact     map[string]bool
+	skipPaths  []string
+	levels     map[int]slog.Level
+	sampleRate float64
+}
+
+// logRequest is the middleware behind LogRequest.
+var logRequest = NewRequestLogger(RequestLoggerOptions{})
+
+// LogRequest logs each incoming HTTP request including the method, URL, protocol,
+// status code, status text, response size and duration of the request.
+//
+// A single entry is logged after the handler returns. The http.ResponseWriter passed
+// to the handler still supports flushing, hijacking and http.ResponseController; a
+// hijacked connection is logged with the 101 (Switching Protocols) status.
+// Credentials in the Authorization, Cookie, Proxy-Authorization and Set-Cookie headers are redacted.
+// Use NewRequestLogger to customize the logged information.
+func LogRequest(next http.Handler) http.Handler {
+	return logRequest(next)
+}
+
+// NewRequestLogger returns a middleware that logs each request after its handler returns,
+// according to the given options.
+func NewRequestLogger(opts RequestLoggerOptions) Middleware {
+	l := &requestLogger{
+		logger:     opts.Logger,
+		fields:     toSet(opts.Fields, func(s string) string { return s }),
+		allow:      toSet(opts.AllowHeaders, http.CanonicalHeaderKey),
+		skipPaths:  opts.SkipPaths,
+		levels:     opts.Levels,
+		sampleRate: opts.SampleRate,
+	}
+
+	redactHeaders := opts.RedactHeaders
+	if redactHeaders == nil {
+		redactHeaders = defaultRedactHeaders
+	}
+	l.redact = toSet(redactHeaders, http.CanonicalHeaderKey)
+
+	return l.middleware
+}
+
+// middleware wraps next with request logging.
+func (l *requestLogger) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if l.skip(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		start := time.Now()
+		rw := newResponseWriter(w)
+
+		next.ServeHTTP(rw, r)
+
+		status := rw.Status()
+		if !l.sampled(status) {
+			return
+		}
+
+		logger, keys := loggerFor(r, l.logger)
+
+		ctx := r.Context()
+		level := l.level(status)
+		if !logger.Enabled(ctx, level) {
+			return
+		}
+
+		logger.LogAttrs(ctx, level, "Request completed", withoutKeys(l.attrs(r, rw, time.Since(start)), keys)...)
+	})
+}
+
+// skip reports whether requests to the given path must not be logged.
+func (l *requestLogger) skip(p string) bool {
+	for _, pattern := range l.skipPaths {
+		if ok, err := path.Match(pattern, p); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// sampled reports whether a request that completed with the given status must be logged.
+func (l *requestLogger) sampled(status int) bool {
+	if l.sampleRate <= 0 || l.sampleRate >= 1 || status >= http.StatusInternalServerError {
+		return true
+	}
+	return rand.Float64() < l.sampleRate //nolint:gosec // sampling does not need a secure source
+}
+
+// level returns the log level of a request that completed with the given status.
+func (l *requestLogger) level(status int) slog.Level {
+	class := status / 100
+	if level, ok := l.levels[class]; ok {
+		return level
+	}
+
+	switch {
+	case class >= 5:
+		return slog.LevelError
+	case class == 4:
+		return slog.LevelWarn
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// attrs returns the selected log attributes of a completed request.
+func (l *requestLogger) attrs(r *http.Request, rw *responseWriter, duration time.Duration) []slog.Attr {
+	status := rw.Status()
+	all := []slog.Attr{
+		slog.String("user_agent", r.UserAgent()),
+		slog.String("remote_address", getIPAddress(r)),
+		slog.String("method", r.Method),
+		slog.String("path", r.URL.Path),
+		slog.String("proto", r.Proto),
+		slog.Any("headers", l.headers(r.Header)),
+		slog.Int("status", status),
+		slog.String("status_text", http.StatusText(status)),
+		slog.Int("bytes", rw.bytes),
+		slog.Duration("duration", duration),
+	}
+
+	if ri, ok := RouteFromContext(r.Context()); ok {
+		all = append(all, slog.String("route", ri.label()))
+	}
+	if id, ok := RequestIDFromContext(r.Context()); ok {
+		all = append(all, slog.String("request_id", id))
+	}
+
+	if len(l.fields) == 0 {
+		return all
+	}
+
+	attrs := make([]slog.Attr, 0, len(l.fields))
+	for _, attr := range all {
+		if l.fields[attr.Key] {
+			attrs = append(attrs, attr)
+		}
+	}
+	return attrs
+}
+
+// headers returns a copy of h restricted to the allowed headers, with redacted values replaced.
+func (l *requestLogger) headers(h http.Header) http.Header {
+	logged := make(http.Header, len(h))
+	for k, v := range h {
+		if len(l.allow) > 0 && !l.allow[k] {
+			continue
+		}
+		if l.redact[k] {
+			v = []string{redacted}
+		}
+		logged[k] = v
+	}
+	return logged
+}
+
+// toSet returns the set of the given values after applying the normalize function.
+func toSet(values []string, normalize func(string) string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[normalize(v)] = true
+	}
+	return set
+}
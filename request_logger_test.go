@@ -1,12 +1,17 @@
 package goexpress_test
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"log/slog"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ferdiebergado/goexpress"
 )
@@ -24,7 +29,12 @@ func (m *mockHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
 
 // logCapture implements slog.Handler to capture log entries for assertions.
 type logCapture struct {
-	entries []map[string]any
+	root     *logCapture // capture receiving the entries of a handler created by WithAttrs
+	attrs    []slog.Attr // attributes added with WithAttrs
+	entries  []map[string]any
+	levels   []slog.Level
+	messages []string
+	counts   []map[string]int // number of attributes of each entry by key
 }
 
 func (l *logCapture) Enabled(_ context.Context, _ slog.Level) bool {
@@ -33,16 +43,34 @@ func (l *logCapture) Enabled(_ context.Context, _ slog.Level) bool {
 
 func (l *logCapture) Handle(_ context.Context, r slog.Record) error {
 	entry := make(map[string]any)
-	r.Attrs(func(a slog.Attr) bool {
+	counts := make(map[string]int)
+	add := func(a slog.Attr) bool {
 		entry[a.Key] = a.Value.Any()
+		counts[a.Key]++
 		return true
-	})
-	l.entries = append(l.entries, entry)
+	}
+	for _, a := range l.attrs {
+		add(a)
+	}
+	r.Attrs(add)
+
+	root := l
+	if l.root != nil {
+		root = l.root
+	}
+	root.entries = append(root.entries, entry)
+	root.levels = append(root.levels, r.Level)
+	root.messages = append(root.messages, r.Message)
+	root.counts = append(root.counts, counts)
 	return nil
 }
 
-func (l *logCapture) WithAttrs(_ []slog.Attr) slog.Handler {
-	return l
+func (l *logCapture) WithAttrs(attrs []slog.Attr) slog.Handler {
+	root := l
+	if l.root != nil {
+		root = l.root
+	}
+	return &logCapture{root: root, attrs: append(slices.Clone(l.attrs), attrs...)}
 }
 
 func (l *logCapture) WithGroup(_ string) slog.Handler {
@@ -187,3 +215,347 @@ func runTestCase(t *testing.T, tc testcase) {
 		}
 	})
 }
+
+func TestLogRequestResponse(t *testing.T) {
+	const body = "created"
+
+	lc := &logCapture{}
+	oldLogger := slog.Default()
+	slog.SetDefault(slog.New(lc))
+	defer slog.SetDefault(oldLogger)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		if _, err := w.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/todos", http.NoBody)
+	rec := httptest.NewRecorder()
+	goexpress.LogRequest(handler).ServeHTTP(rec, req)
+
+	if len(lc.entries) != 1 {
+		t.Fatalf("len(entries) = %d, want: 1", len(lc.entries))
+	}
+	entry := lc.entries[0]
+
+	if got := entry["status"]; got != int64(http.StatusCreated) {
+		t.Errorf("Logged status = %v; want %v", got, http.StatusCreated)
+	}
+	if got := entry["status_text"]; got != http.StatusText(http.StatusCreated) {
+		t.Errorf("Logged status_text = %v; want %v", got, http.StatusText(http.StatusCreated))
+	}
+	if got := entry["bytes"]; got != int64(len(body)) {
+		t.Errorf("Logged bytes = %v; want %v", got, len(body))
+	}
+	if _, ok := entry["duration"].(time.Duration); !ok {
+		t.Errorf("Logged duration missing or wrong type")
+	}
+}
+
+func TestLogRequestFlush(t *testing.T) {
+	oldLogger := slog.Default()
+	slog.SetDefault(slog.New(&logCapture{}))
+	defer slog.SetDefault(oldLogger)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if _, ok := w.(http.Flusher); !ok {
+			t.Fatal("response writer does not implement http.Flusher")
+		}
+		if err := http.NewResponseController(w).Flush(); err != nil {
+			t.Fatalf("flush: %v", err)
+		}
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/stream", http.NoBody)
+	rec := httptest.NewRecorder()
+	goexpress.LogRequest(handler).ServeHTTP(rec, req)
+
+	if !rec.Flushed {
+		t.Error("response was not flushed")
+	}
+}
+
+// plainWriter is an http.ResponseWriter that supports neither flushing nor hijacking.
+type plainWriter struct {
+	header http.Header
+}
+
+func (w *plainWriter) Header() http.Header         { return w.header }
+func (w *plainWriter) Write(b []byte) (int, error) { return len(b), nil }
+func (w *plainWriter) WriteHeader(int)             {}
+
+func TestLogRequestFlushNotSupported(t *testing.T) {
+	oldLogger := slog.Default()
+	slog.SetDefault(slog.New(&logCapture{}))
+	defer slog.SetDefault(oldLogger)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if err := http.NewResponseController(w).Flush(); !errors.Is(err, http.ErrNotSupported) {
+			t.Errorf("flush error = %v, want: %v", err, http.ErrNotSupported)
+		}
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/stream", http.NoBody)
+	goexpress.LogRequest(handler).ServeHTTP(&plainWriter{header: make(http.Header)}, req)
+}
+
+func TestLogRequestHijack(t *testing.T) {
+	lc := &logCapture{}
+	logger := goexpress.NewRequestLogger(goexpress.RequestLoggerOptions{Logger: slog.New(lc)})
+
+	done := make(chan struct{})
+	hijack := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		conn, brw, err := http.NewResponseController(w).Hijack()
+		if err != nil {
+			t.Errorf("hijack: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		brw.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: test\r\n\r\n")
+		if err := brw.Flush(); err != nil {
+			t.Errorf("write: %v", err)
+		}
+	})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer close(done)
+		logger(hijack).ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	conn, err := net.Dial("tcp", srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("GET /ws HTTP/1.1\r\nHost: example.com\r\nConnection: Upgrade\r\nUpgrade: test\r\n\r\n")); err != nil {
+		t.Fatal(err)
+	}
+	res, err := http.ReadResponse(bufio.NewReader(conn), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusSwitchingProtocols {
+		t.Errorf("status = %d, want: %d", res.StatusCode, http.StatusSwitchingProtocols)
+	}
+
+	<-done
+	if len(lc.entries) != 1 {
+		t.Fatalf("len(entries) = %d, want: 1", len(lc.entries))
+	}
+	if got := lc.entries[0]["status"]; got != int64(http.StatusSwitchingProtocols) {
+		t.Errorf("Logged status = %v; want %v", got, http.StatusSwitchingProtocols)
+	}
+}
+
+func TestNewRequestLogger(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		opts      goexpress.RequestLoggerOptions
+		path      string
+		status    int
+		headers   map[string]string
+		wantLogs  bool
+		wantLevel slog.Level
+		wantKeys  []string
+		wantHdrs  map[string]string
+	}{
+		{
+			name:      "default options",
+			path:      "/todos",
+			status:    http.StatusOK,
+			wantLogs:  true,
+			wantLevel: slog.LevelInfo,
+			wantKeys:  []string{"method", "path", "status", "bytes", "duration", "headers"},
+		},
+		{
+			name:   "redacts credentials by default",
+			path:   "/todos",
+			status: http.StatusOK,
+			headers: map[string]string{
+				"Authorization": "Bearer secret",
+				"Cookie":        "session=secret",
+				"Accept":        "text/html",
+			},
+			wantLogs:  true,
+			wantLevel: slog.LevelInfo,
+			wantHdrs: map[string]string{
+				"Authorization": "[REDACTED]",
+				"Cookie":        "[REDACTED]",
+				"Accept":        "text/html",
+			},
+		},
+		{
+			name:   "custom redacted and allowed headers",
+			opts:   goexpress.RequestLoggerOptions{AllowHeaders: []string{"x-api-key", "accept"}, RedactHeaders: []string{"X-Api-Key"}},
+			path:   "/todos",
+			status: http.StatusOK,
+			headers: map[string]string{
+				"X-Api-Key": "secret",
+				"Accept":    "text/html",
+				"X-Other":   "other",
+			},
+			wantLogs:  true,
+			wantLevel: slog.LevelInfo,
+			wantHdrs: map[string]string{
+				"X-Api-Key": "[REDACTED]",
+				"Accept":    "text/html",
+				"X-Other":   "",
+			},
+		},
+		{
+			name:     "skipped path",
+			opts:     goexpress.RequestLoggerOptions{SkipPaths: []string{"/health*"}},
+			path:     "/healthz",
+			status:   http.StatusOK,
+			wantLogs: false,
+		},
+		{
+			name:      "selected fields",
+			opts:      goexpress.RequestLoggerOptions{Fields: []string{"method", "status"}},
+			path:      "/todos",
+			status:    http.StatusOK,
+			wantLogs:  true,
+			wantLevel: slog.LevelInfo,
+			wantKeys:  []string{"method", "status"},
+		},
+		{
+			name:      "client error level",
+			path:      "/todos",
+			status:    http.StatusNotFound,
+			wantLogs:  true,
+			wantLevel: slog.LevelWarn,
+		},
+		{
+			name:      "server error level",
+			path:      "/todos",
+			status:    http.StatusInternalServerError,
+			wantLogs:  true,
+			wantLevel: slog.LevelError,
+		},
+		{
+			name:      "custom level",
+			opts:      goexpress.RequestLoggerOptions{Levels: map[int]slog.Level{2: slog.LevelDebug}},
+			path:      "/todos",
+			status:    http.StatusOK,
+			wantLogs:  true,
+			wantLevel: slog.LevelDebug,
+		},
+		{
+			name:      "sampling keeps server errors",
+			opts:      goexpress.RequestLoggerOptions{SampleRate: 0.000001},
+			path:      "/todos",
+			status:    http.StatusBadGateway,
+			wantLogs:  true,
+			wantLevel: slog.LevelError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			lc := &logCapture{}
+			opts := tt.opts
+			opts.Logger = slog.New(lc)
+
+			handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, http.NoBody)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			goexpress.NewRequestLogger(opts)(handler).ServeHTTP(rec, req)
+
+			if !tt.wantLogs {
+				if len(lc.entries) != 0 {
+					t.Fatalf("len(entries) = %d, want: 0", len(lc.entries))
+				}
+				return
+			}
+
+			if len(lc.entries) != 1 {
+				t.Fatalf("len(entries) = %d, want: 1", len(lc.entries))
+			}
+			entry := lc.entries[0]
+
+			if lc.levels[0] != tt.wantLevel {
+				t.Errorf("level = %v, want: %v", lc.levels[0], tt.wantLevel)
+			}
+
+			if len(tt.wantKeys) > 0 && len(tt.opts.Fields) > 0 && len(entry) != len(tt.wantKeys) {
+				t.Errorf("len(entry) = %d, want: %d", len(entry), len(tt.wantKeys))
+			}
+			for _, key := range tt.wantKeys {
+				if _, ok := entry[key]; !ok {
+					t.Errorf("entry[%q] is missing", key)
+				}
+			}
+
+			if tt.wantHdrs != nil {
+				headers, ok := entry["headers"].(http.Header)
+				if !ok {
+					t.Fatal("Logged headers missing or wrong type")
+				}
+				for k, v := range tt.wantHdrs {
+					if got := headers.Get(k); got != v {
+						t.Errorf("headers.Get(%q) = %q, want: %q", k, got, v)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRequestLoggerRoute(t *testing.T) {
+	t.Parallel()
+
+	lc := &logCapture{}
+	r := goexpress.New()
+	r.Use(goexpress.NewRequestLogger(goexpress.RequestLoggerOptions{Logger: slog.New(lc)}))
+	r.Get("/users/{id}", http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {}))
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42", http.NoBody)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	if len(lc.entries) != 1 {
+		t.Fatalf("len(entries) = %d, want: 1", len(lc.entries))
+	}
+	if got, want := lc.entries[0]["route"], "/users/{id}"; got != want {
+		t.Errorf("Logged route = %v; want %v", got, want)
+	}
+	if got, want := lc.entries[0]["path"], "/users/42"; got != want {
+		t.Errorf("Logged path = %v; want %v", got, want)
+	}
+}
+
+func TestRequestLoggerHostRoute(t *testing.T) {
+	t.Parallel()
+
+	lc := &logCapture{}
+	r := goexpress.New()
+	r.Use(goexpress.NewRequestLogger(goexpress.RequestLoggerOptions{Logger: slog.New(lc)}))
+	r.Host("{tenant}.example.com", func(tenant *goexpress.Router) {
+		tenant.Get("/dash", http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {}))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/dash", http.NoBody)
+	req.Host = "acme.example.com"
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	if len(lc.entries) != 1 {
+		t.Fatalf("len(entries) = %d, want: 1", len(lc.entries))
+	}
+	if got, want := lc.entries[0]["route"], "{tenant}.example.com/dash"; got != want {
+		t.Errorf("Logged route = %v; want %v", got, want)
+	}
+}
@@ -0,0 +1,118 @@
+package goexpress
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Indexer is implemented by resource controllers that list the resources.
+type Indexer interface {
+	Index(w http.ResponseWriter, r *http.Request)
+}
+
+// Shower is implemented by resource controllers that show a resource.
+type Shower interface {
+	Show(w http.ResponseWriter, r *http.Request)
+}
+
+// Creator is implemented by resource controllers that create a resource.
+type Creator interface {
+	Create(w http.ResponseWriter, r *http.Request)
+}
+
+// Updater is implemented by resource controllers that update a resource.
+type Updater interface {
+	Update(w http.ResponseWriter, r *http.Request)
+}
+
+// Deleter is implemented by resource controllers that delete a resource.
+type Deleter interface {
+	Delete(w http.ResponseWriter, r *http.Request)
+}
+
+// ResourceAction identifies an action of a resource controller.
+type ResourceAction string
+
+// Actions of resource controllers.
+const (
+	ActionIndex  ResourceAction = "index"  // GET path, implemented by Indexer
+	ActionShow   ResourceAction = "show"   // GET path/{id}, implemented by Shower
+	ActionCreate ResourceAction = "create" // POST path, implemented by Creator
+	ActionUpdate ResourceAction = "update" // PUT and PATCH path/{id}, implemented by Updater
+	ActionDelete ResourceAction = "delete" // DELETE path/{id}, implemented by Deleter
+)
+
+// ResourceOption configures the routes registered by Router.Resource.
+type ResourceOption func(*resourceConfig)
+
+// resourceConfig is the configuration of the routes of a resource.
+type resourceConfig struct {
+	middlewares map[ResourceAction][]Middleware // route-specific middlewares by action
+}
+
+// WithActionMiddleware applies the middlewares to the route of the action, after the
+// middlewares of the router. It can be repeated to add middlewares to several actions.
+func WithActionMiddleware(action ResourceAction, middlewares ...Middleware) ResourceOption {
+	return func(c *resourceConfig) {
+		c.middlewares[action] = append(c.middlewares[action], middlewares...)
+	}
+}
+
+// Resource registers the conventional REST routes for the actions implemented by the
+// controller, which can implement any of Indexer, Shower, Creator, Updater and Deleter:
+//
+//	GET    path       Index
+//	POST   path       Create
+//	GET    path/{id}  Show
+//	PUT    path/{id}  Update
+//	PATCH  path/{id}  Update
+//	DELETE path/{id}  Delete
+//
+// The identifier of the resource is available with r.PathValue("id"). It panics if the
+// controller implements none of the interfaces.
+func (r *Router) Resource(p string, ctrl any, opts ...ResourceOption) {
+	cfg := resourceConfig{middlewares: make(map[ResourceAction][]Middleware)}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	collection := normalizePath(p)
+	member := normalizePath(collection + "/{id}")
+
+	var actions []resourceRoute
+	if c, ok := ctrl.(Indexer); ok {
+		actions = append(actions, resourceRoute{ActionIndex, "Index", []string{http.MethodGet}, collection, c.Index})
+	}
+	if c, ok := ctrl.(Creator); ok {
+		actions = append(actions, resourceRoute{ActionCreate, "Create", []string{http.MethodPost}, collection, c.Create})
+	}
+	if c, ok := ctrl.(Shower); ok {
+		actions = append(actions, resourceRoute{ActionShow, "Show", []string{http.MethodGet}, member, c.Show})
+	}
+	if c, ok := ctrl.(Updater); ok {
+		actions = append(actions, resourceRoute{ActionUpdate, "Update", []string{http.MethodPut, http.MethodPatch}, member, c.Update})
+	}
+	if c, ok := ctrl.(Deleter); ok {
+		actions = append(actions, resourceRoute{ActionDelete, "Delete", []string{http.MethodDelete}, member, c.Delete})
+	}
+
+	if len(actions) == 0 {
+		panic(fmt.Sprintf("goexpress: resource %q: %T implements none of the resource controller interfaces", p, ctrl))
+	}
+
+	for _, a := range actions {
+		for _, rt := range r.Match(a.methods, a.path, a.handler, cfg.middlewares[a.action]...) {
+			// The function name of an interface method value is the name of the interface method.
+			rt.route.desc = fmt.Sprintf("%T.%s", ctrl, a.name)
+		}
+	}
+}
+
+// resourceRoute is a route of an action implemented by a resource controller.
+type resourceRoute struct {
+	action  ResourceAction   // action of the route
+	name    string           // name of the controller method
+	methods []string         // HTTP methods of the route
+	path    string           // path pattern of the route
+	handler http.HandlerFunc // controller method
+}
@@ -0,0 +1,157 @@
+package goexpress_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ferdiebergado/goexpress"
+)
+
+type todoController struct{}
+
+func (todoController) Index(w http.ResponseWriter, _ *http.Request) {
+	w.Write([]byte("index"))
+}
+
+func (todoController) Show(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte("show " + r.PathValue("id")))
+}
+
+func (todoController) Create(w http.ResponseWriter, _ *http.Request) {
+	w.Write([]byte("create"))
+}
+
+func (todoController) Update(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte("update " + r.PathValue("id")))
+}
+
+func (todoController) Delete(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte("delete " + r.PathValue("id")))
+}
+
+type readOnlyController struct{}
+
+func (readOnlyController) Index(w http.ResponseWriter, _ *http.Request) {
+	w.Write([]byte("index"))
+}
+
+func TestResource(t *testing.T) {
+	t.Parallel()
+
+	const header = "X-Action"
+
+	mw := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(header, "protected")
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	r := goexpress.New()
+	r.Resource("/todos", todoController{},
+		goexpress.WithActionMiddleware(goexpress.ActionCreate, mw),
+		goexpress.WithActionMiddleware(goexpress.ActionDelete, mw),
+	)
+	r.Group("/api", func(api *goexpress.Router) {
+		api.Resource("/reports", readOnlyController{})
+	})
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+		wantBody   string
+		wantHeader string
+	}{
+		{
+			name:       "index",
+			method:     http.MethodGet,
+			path:       "/todos",
+			wantStatus: http.StatusOK,
+			wantBody:   "index",
+		},
+		{
+			name:       "show",
+			method:     http.MethodGet,
+			path:       "/todos/1",
+			wantStatus: http.StatusOK,
+			wantBody:   "show 1",
+		},
+		{
+			name:       "create with action middleware",
+			method:     http.MethodPost,
+			path:       "/todos",
+			wantStatus: http.StatusOK,
+			wantBody:   "create",
+			wantHeader: "protected",
+		},
+		{
+			name:       "update with PUT",
+			method:     http.MethodPut,
+			path:       "/todos/1",
+			wantStatus: http.StatusOK,
+			wantBody:   "update 1",
+		},
+		{
+			name:       "update with PATCH",
+			method:     http.MethodPatch,
+			path:       "/todos/2",
+			wantStatus: http.StatusOK,
+			wantBody:   "update 2",
+		},
+		{
+			name:       "delete with action middleware",
+			method:     http.MethodDelete,
+			path:       "/todos/3",
+			wantStatus: http.StatusOK,
+			wantBody:   "delete 3",
+			wantHeader: "protected",
+		},
+		{
+			name:       "partial controller in group",
+			method:     http.MethodGet,
+			path:       "/api/reports",
+			wantStatus: http.StatusOK,
+			wantBody:   "index",
+		},
+		{
+			name:       "unimplemented action",
+			method:     http.MethodPost,
+			path:       "/api/reports",
+			wantStatus: http.StatusMethodNotAllowed,
+			wantBody:   "Method Not Allowed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(tt.method, tt.path, http.NoBody)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assertStatus(t, rec.Code, tt.wantStatus)
+			assertBody(t, rec.Body.String(), tt.wantBody)
+			assertHeader(t, rec, header, tt.wantHeader)
+		})
+	}
+
+	if got, want := r.Routes()[0].Handler, "goexpress_test.todoController.Index"; got != want {
+		t.Errorf("r.Routes()[0].Handler = %q, want: %q", got, want)
+	}
+}
+
+func TestResourceWithoutActions(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("r.Resource() did not panic, want: panic for a controller without actions")
+		}
+	}()
+
+	goexpress.New().Resource("/todos", struct{}{})
+}
@@ -0,0 +1,92 @@
+package goexpress
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+)
+
+// responseWriter wraps an http.ResponseWriter to record the status code and the
+// number of bytes written in the response.
+//
+// It implements http.Flusher and http.Hijacker by delegating to the wrapped writer,
+// and exposes it through Unwrap so that http.ResponseController keeps working.
+// A hijacked connection is recorded with the 101 (Switching Protocols) status, since
+// the response written on the connection is not seen.
+type responseWriter struct {
+	http.ResponseWriter
+	status      int  // status code sent to the client
+	bytes       int  // number of bytes written in the body
+	wroteHeader bool // whether the final response header has been written
+}
+
+// newResponseWriter wraps w in a responseWriter.
+func newResponseWriter(w http.ResponseWriter) *responseWriter {
+	return &responseWriter{ResponseWriter: w}
+}
+
+// WriteHeader records the status code and sends the response header.
+func (rw *responseWriter) WriteHeader(status int) {
+	// Informational responses may be sent any number of times before the final header.
+	if !rw.wroteHeader && (status < 100 || status > 199 || status == http.StatusSwitchingProtocols) {
+		rw.status = status
+		rw.wroteHeader = true
+	}
+	rw.ResponseWriter.WriteHeader(status)
+}
+
+// Write writes the data to the response body, recording the number of bytes written.
+func (rw *responseWriter) Write(b []byte) (int, error) {
+	rw.markHeaderWritten()
+	n, err := rw.ResponseWriter.Write(b)
+	rw.bytes += n
+	return n, err
+}
+
+// Flush sends any buffered data to the client if the wrapped writer supports it.
+func (rw *responseWriter) Flush() {
+	rw.FlushError() //nolint:errcheck // http.Flusher cannot report errors
+}
+
+// FlushError sends any buffered data to the client, or returns http.ErrNotSupported if the
+// wrapped writer does not support flushing. It is used by http.ResponseController.
+func (rw *responseWriter) FlushError() error {
+	err := http.NewResponseController(rw.ResponseWriter).Flush()
+	if !errors.Is(err, http.ErrNotSupported) {
+		rw.markHeaderWritten()
+	}
+	return err //nolint:wrapcheck // errors are part of the contract
+}
+
+// Hijack lets the caller take over the connection if the wrapped writer supports it.
+func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, brw, err := http.NewResponseController(rw.ResponseWriter).Hijack()
+	if err == nil && !rw.wroteHeader {
+		rw.status = http.StatusSwitchingProtocols
+		rw.wroteHeader = true
+	}
+	return conn, brw, err //nolint:wrapcheck // errors are part of the contract
+}
+
+// Unwrap returns the wrapped http.ResponseWriter for use by http.ResponseController.
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+// Status returns the status code of the response, defaulting to 200 when no header has been written.
+func (rw *responseWriter) Status() int {
+	if rw.status == 0 {
+		return http.StatusOK
+	}
+	return rw.status
+}
+
+// markHeaderWritten records the implicit 200 status sent when the body is written
+// without an explicit call to WriteHeader.
+func (rw *responseWriter) markHeaderWritten() {
+	if !rw.wroteHeader {
+		rw.status = http.StatusOK
+		rw.wroteHeader = true
+	}
+}
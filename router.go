@@ -7,7 +7,10 @@ import (
 	"path"
 	"reflect"
 	"runtime"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 // Middleware defines the signature for a standard net/http middleware function.
@@ -30,74 +33,224 @@ type Middleware func(http.Handler) http.Handler
 // Router is a custom HTTP router built on top of http.ServeMux with support for global
 // and route-specific middleware. It allows easy route registration for common HTTP methods
 // (GET, POST, PATCH, PUT, DELETE) and provides a flexible middleware chain for request handling.
+//
+// Route paths accept the http.ServeMux wildcards {name}, {name...} and {$}, as well as the
+// Express-style parameters :name and *name, which are translated to {name} and {name...}.
+// Registering an invalid or conflicting path panics with an error wrapping ErrInvalidPattern.
+//
+// The middleware chains are composed when the router serves its first request, so middlewares
+// apply to all the routes regardless of the order of registration. Registering routes or
+// middlewares after the router has started serving requests panics.
 type Router struct {
-	prefix      string         // prefix for the paths of registered routes
-	mux         *http.ServeMux // underlying HTTP request multiplexer
-	routes      []route        // slice to store the registered routes
-	middlewares []Middleware   // slice to store global middlewares
+	prefix      string       // prefix for the paths of registered routes
+	host        string       // host pattern of registered routes, empty for any host
+	hostMux     *hostMux     // mux of a host pattern with wildcards, nil otherwise
+	parent      *Router      // enclosing router of a route group, nil for the top-level router
+	middlewares []Middleware // middlewares of the router or route group
+	core        *routerCore  // state shared by the router and its route groups
+}
+
+// routerCore holds the state shared by a router and all of its route groups.
+type routerCore struct {
+	mux              *http.ServeMux               // underlying HTTP request multiplexer
+	hosts            []*hostMux                   // muxes of host patterns with wildcards
+	routes           []*route                     // registered routes
+	names            map[string]*route            // named routes
+	mounts           []*route                     // registered method-less handlers, e.g. static files and NotFound
+	methodNotAllowed *route                       // handler for requests with an unsupported method
+	defaultNotFound  *route                       // handler for requests that match no route nor NotFound handler
+	autoOptions      bool                         // whether OPTIONS requests are answered automatically
+	indexes          map[*http.ServeMux]*muxIndex // routes by mux, indexed when the router is built
+	once             sync.Once                    // composes the middleware chains on the first request
+	serving          atomic.Bool                  // whether the router has started serving requests
+}
+
+// muxIndex indexes the routes registered in a ServeMux, to answer the requests with an
+// unsupported method.
+type muxIndex struct {
+	methods  []string          // sorted methods of the routes, including HEAD if GET is registered
+	patterns map[string]*route // routes by ServeMux pattern
+	fallback *unmatched        // handler of the requests that match no pattern, nil if "/" is mounted
 }
 
 // New creates and returns a custom HTTP router.
 func New() *Router {
-	return &Router{
-		mux: http.NewServeMux(),
+	r := &Router{
+		core: &routerCore{
+			mux: http.NewServeMux(),
+		},
 	}
+	r.core.methodNotAllowed = &route{handler: http.HandlerFunc(methodNotAllowed), router: r}
+	r.core.defaultNotFound = &route{handler: http.NotFoundHandler(), router: r}
+	return r
 }
 
-// Use appends the given middleware to the router's global middleware chain. Each middleware
-// added with Use will be applied to every request handled by this Router.
+// Use appends the given middleware to the router's middleware chain. Each middleware
+// added with Use will be applied to every request handled by this Router, including
+// the routes registered before the call. Within a route group, the middleware applies
+// to the routes of the group.
 func (r *Router) Use(mw Middleware) {
+	r.mustNotServe()
 	r.middlewares = append(r.middlewares, mw)
 }
 
 // Get registers a new GET route for the specified path and handler, applying any optional middleware.
-func (r *Router) Get(p string, handler http.Handler, middlewares ...Middleware) {
-	r.handle(http.MethodGet, p, handler, middlewares...)
+func (r *Router) Get(p string, handler http.Handler, middlewares ...Middleware) *Route {
+	return r.handle(http.MethodGet, p, handler, middlewares...)
 }
 
 // Post registers a new POST route for the specified path and handler, applying any optional middleware.
-func (r *Router) Post(p string, handler http.Handler, middlewares ...Middleware) {
-	r.handle(http.MethodPost, p, handler, middlewares...)
+func (r *Router) Post(p string, handler http.Handler, middlewares ...Middleware) *Route {
+	return r.handle(http.MethodPost, p, handler, middlewares...)
 }
 
 // Patch registers a new PATCH route for the specified path and handler, applying any optional middleware.
-func (r *Router) Patch(p string, handler http.Handler, middlewares ...Middleware) {
-	r.handle(http.MethodPatch, p, handler, middlewares...)
+func (r *Router) Patch(p string, handler http.Handler, middlewares ...Middleware) *Route {
+	return r.handle(http.MethodPatch, p, handler, middlewares...)
 }
 
 // Put registers a new PUT route for the specified path and handler, applying any optional middleware.
-func (r *Router) Put(p string, handler http.Handler, middlewares ...Middleware) {
-	r.handle(http.MethodPut, p, handler, middlewares...)
+func (r *Router) Put(p string, handler http.Handler, middlewares ...Middleware) *Route {
+	return r.handle(http.MethodPut, p, handler, middlewares...)
 }
 
 // Delete registers a new DELETE route for the specified path and handler, applying any optional middleware.
-func (r *Router) Delete(p string, handler http.Handler, middlewares ...Middleware) {
-	r.handle(http.MethodDelete, p, handler, middlewares...)
+func (r *Router) Delete(p string, handler http.Handler, middlewares ...Middleware) *Route {
+	return r.handle(http.MethodDelete, p, handler, middlewares...)
 }
 
 // Connect registers a new route that responds to HTTP CONNECT requests for the specified path.
-func (r *Router) Connect(p string, handler http.Handler, middlewares ...Middleware) {
-	r.handle(http.MethodConnect, p, handler, middlewares...)
+func (r *Router) Connect(p string, handler http.Handler, middlewares ...Middleware) *Route {
+	return r.handle(http.MethodConnect, p, handler, middlewares...)
 }
 
 // Options registers a new route that responds to HTTP OPTIONS requests for the specified path.
-func (r *Router) Options(p string, handler http.Handler, middlewares ...Middleware) {
-	r.handle(http.MethodOptions, p, handler, middlewares...)
+func (r *Router) Options(p string, handler http.Handler, middlewares ...Middleware) *Route {
+	return r.handle(http.MethodOptions, p, handler, middlewares...)
 }
 
 // Trace registers a new route that responds to HTTP TRACE requests for the specified path.
-func (r *Router) Trace(p string, handler http.Handler, middlewares ...Middleware) {
-	r.handle(http.MethodTrace, p, handler, middlewares...)
+func (r *Router) Trace(p string, handler http.Handler, middlewares ...Middleware) *Route {
+	return r.handle(http.MethodTrace, p, handler, middlewares...)
 }
 
 // Head registers a new route that responds to HTTP HEAD requests for the specified path.
-func (r *Router) Head(p string, handler http.Handler, middlewares ...Middleware) {
-	r.handle(http.MethodHead, p, handler, middlewares...)
+func (r *Router) Head(p string, handler http.Handler, middlewares ...Middleware) *Route {
+	return r.handle(http.MethodHead, p, handler, middlewares...)
+}
+
+// anyMethods are the methods registered by Any.
+var anyMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodConnect,
+	http.MethodOptions,
+	http.MethodTrace,
+}
+
+// Match registers a route for each of the specified methods with the same path, handler and
+// optional middleware, and returns the registered routes in the order of the methods.
+// Methods are uppercased and duplicates are registered once. It panics if no method is given.
+func (r *Router) Match(methods []string, p string, handler http.Handler, middlewares ...Middleware) []*Route {
+	if len(methods) == 0 {
+		panic("goexpress: Match requires at least one method")
+	}
+
+	seen := make([]string, 0, len(methods))
+	routes := make([]*Route, 0, len(methods))
+	for _, method := range methods {
+		method = strings.ToUpper(method)
+		if method == "" {
+			panic("goexpress: Match method must not be empty")
+		}
+		if slices.Contains(seen, method) {
+			continue
+		}
+		seen = append(seen, method)
+
+		routes = append(routes, r.handle(method, p, handler, middlewares...))
+	}
+	return routes
+}
+
+// Any registers a route for each of the standard HTTP methods with the same path, handler
+// and optional middleware. The OPTIONS route takes precedence over AutoOptions for the path.
+func (r *Router) Any(p string, handler http.Handler, middlewares ...Middleware) []*Route {
+	return r.Match(anyMethods, p, handler, middlewares...)
 }
 
 // ServeHTTP enables the Router to satisfy the http.Handler interface.
+//
+// Requests to a registered path with a method that has no route are answered with
+// a 405 (Method Not Allowed) response listing the supported methods in the Allow header.
+// OPTIONS requests are answered automatically when enabled with AutoOptions. Requests that
+// match no route are answered with a 404 (Not Found) response. These responses go through
+// the global middlewares.
 func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	r.mux.ServeHTTP(w, req)
+	c := r.core
+	c.once.Do(c.build)
+
+	for _, h := range c.hosts {
+		values, ok := h.match(req.Host)
+		if !ok {
+			continue
+		}
+
+		// Requests that match no route of the host are served by the routes for any host.
+		if handler, _ := h.mux.Handler(req); handler == c.indexes[h.mux].fallback {
+			if allowed, _ := c.allowedMethods(h.mux, req); len(allowed) == 0 {
+				continue
+			}
+		}
+
+		for name, value := range values {
+			req.SetPathValue(name, value)
+		}
+		h.mux.ServeHTTP(w, req)
+		return
+	}
+
+	c.mux.ServeHTTP(w, req)
+}
+
+// unmatched is the handler registered in a ServeMux for a method-less pattern: static files, a
+// NotFound handler, or the fallback for the requests that match no pattern. Requests to the path
+// of a route registered with another method are answered with the allowed methods instead.
+type unmatched struct {
+	core    *routerCore
+	mux     *http.ServeMux
+	handler http.Handler // static files or NotFound handler, nil for the fallback
+}
+
+// ServeHTTP answers the requests with an unsupported method and the OPTIONS requests from the
+// registered routes, and serves the others with the handler or as not found.
+func (u *unmatched) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	c := u.core
+	if allowed, matched := c.allowedMethods(u.mux, req); len(allowed) > 0 {
+		if c.autoOptions {
+			allowed = append(allowed, http.MethodOptions)
+			slices.Sort(allowed)
+		}
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+
+		if c.autoOptions && req.Method == http.MethodOptions {
+			matched.options.ServeHTTP(w, req)
+			return
+		}
+
+		c.methodNotAllowed.final.ServeHTTP(w, req)
+		return
+	}
+
+	if u.handler == nil {
+		c.defaultNotFound.final.ServeHTTP(w, req)
+		return
+	}
+	u.handler.ServeHTTP(w, req)
 }
 
 // Group creates a new route group with a common prefix and applies the
@@ -106,37 +259,100 @@ func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 // Middlewares for the route group can also be specified as the last arguments.
 // Nested route groups are also supported.
 func (r *Router) Group(prefix string, fn func(*Router), middlewares ...Middleware) {
+	r.mustNotServe()
+
 	sub := &Router{
-		mux:         r.mux,
 		prefix:      r.prefix + prefix,
-		middlewares: append(append([]Middleware{}, r.middlewares...), middlewares...),
+		host:        r.host,
+		hostMux:     r.hostMux,
+		parent:      r,
+		middlewares: slices.Clone(middlewares),
+		core:        r.core,
 	}
 
 	fn(sub)
-
-	r.routes = append(r.routes, sub.routes...)
 }
 
 // Static serves static files from the specified local directory path at the given url prefix.
+// Within a route group, the url prefix is relative to the prefix of the group, and the
+// middlewares of the group are applied.
 func (r *Router) Static(prefix, dir string) {
-	fullPrefix := normalizePath(prefix)
-	handler := http.StripPrefix(fullPrefix, http.FileServer(http.Dir(dir)))
-	wrappedHandler := r.wrap(handler, r.middlewares)
+	r.mustNotServe()
 
-	pattern := fullPrefix
-	if !strings.HasSuffix(pattern, "/") {
-		pattern += "/"
-	}
+	fullPrefix := normalizePath(r.prefix + "/" + prefix)
+	handler := http.StripPrefix(fullPrefix, http.FileServer(http.Dir(dir)))
 
-	r.mux.Handle(pattern, wrappedHandler)
+	m := r.mount(KindStatic, subtreePattern(fullPrefix), handler)
+	m.desc = "http.FileServer(" + dir + ")"
 }
 
 // NotFound sets a custom handler for requests that don't match any registered route.
 // When a request is made to an undefined route, this handler will be invoked,
 // allowing a custom "Not Found" page or response to be returned.
+//
+// Within a route group, the handler only applies to the requests under the prefix of the
+// group and is wrapped with the middlewares of the group, e.g. to respond with JSON to
+// unknown API paths while the top-level router serves an HTML page. The requests to the
+// prefix itself, e.g. /api, are handled as well rather than redirected to /api/.
 func (r *Router) NotFound(handler http.Handler) {
-	finalHandler := r.wrap(handler, r.middlewares)
-	r.mux.Handle("/", finalHandler)
+	r.mustNotServe()
+
+	prefix := normalizePath(r.prefix)
+	m := r.mount(KindNotFound, subtreePattern(prefix), handler)
+	if prefix != "/" {
+		handlePattern(m.mux, strings.TrimSuffix(m.pattern, "/"), &unmatched{core: r.core, mux: m.mux, handler: m})
+	}
+}
+
+// Mount attaches a handler under the given prefix, which is stripped from the request path
+// before the handler is invoked. The handler is wrapped with the middlewares of the router.
+//
+// The handler can be an independently constructed *Router, e.g. from another package, whose
+// routes then appear with the prefix in the route introspection of this router and whose
+// named routes can be resolved with URL. Any other http.Handler can be mounted as well.
+// The requests under the prefix reach the handler even if their path matches a route of this
+// router with another method, so that the handler answers them on its own.
+func (r *Router) Mount(prefix string, h http.Handler) {
+	r.mustNotServe()
+
+	fullPrefix := normalizePath(r.prefix + "/" + prefix)
+	handler := h
+	if fullPrefix != "/" {
+		handler = http.StripPrefix(fullPrefix, h)
+	}
+
+	m := r.mount(KindMount, subtreePattern(fullPrefix), handler)
+	m.desc = handlerName(h)
+	if sub, ok := h.(*Router); ok {
+		m.sub = sub
+		m.desc = "goexpress.Router"
+	}
+}
+
+// MethodNotAllowed sets a custom handler for requests to a registered path with a method
+// that has no route. The Allow header listing the supported methods is set before the
+// handler is invoked. By default, a plain-text 405 (Method Not Allowed) response is returned.
+//
+// The handler applies to the whole router and is wrapped with its global middlewares, like
+// the default response. It panics if called on a route group or a host.
+func (r *Router) MethodNotAllowed(handler http.Handler) {
+	r.mustNotServe()
+	if r.parent != nil {
+		panic("goexpress: MethodNotAllowed must be called on the top-level router")
+	}
+	r.core.methodNotAllowed = &route{handler: handler, router: r}
+}
+
+// AutoOptions enables or disables automatic responses to OPTIONS requests.
+//
+// When enabled, an OPTIONS request to a registered path without an explicit Options route
+// is answered with a 204 (No Content) response whose Allow header lists the methods of the
+// routes for that path. The response is wrapped with the middlewares of the router or route
+// group that registered the path, so that middlewares such as CORS can handle preflight requests.
+// Routes registered with Options take precedence over the automatic response.
+func (r *Router) AutoOptions(enabled bool) {
+	r.mustNotServe()
+	r.core.autoOptions = enabled
 }
 
 // String returns the middlewares and routes registered in the Router as a string.
@@ -150,7 +366,7 @@ func (r *Router) String() string {
 	}
 
 	s.Write([]byte("\nRoutes:\n"))
-	for _, r := range r.routes {
+	for _, r := range r.core.routes {
 		s.Write([]byte(r.String() + "\n"))
 	}
 	return s.String()
@@ -158,26 +374,201 @@ func (r *Router) String() string {
 
 // handle registers a handle with a specified HTTP method and path, applying
 // any optional middlewares to the handler.
-func (r *Router) handle(method, p string, handler http.Handler, mws ...Middleware) {
-	fullPath := normalizePath(r.prefix + "/" + p)
-	pattern := method + " " + fullPath
-	routeHandler := r.wrap(handler, mws)
-	finalHandler := r.wrap(routeHandler, r.middlewares)
-	r.mux.Handle(pattern, finalHandler)
-
-	newRoute := route{
+func (r *Router) handle(method, p string, handler http.Handler, mws ...Middleware) *Route {
+	r.mustNotServe()
+
+	fullPath, constraints, err := translatePath(normalizePath(r.prefix + "/" + p))
+	if err != nil {
+		panic(err)
+	}
+
+	newRoute := &route{
+		kind:        KindRoute,
 		method:      method,
 		path:        fullPath,
+		constraints: constraints,
 		handler:     handler,
 		middlewares: mws,
+		router:      r,
+	}
+
+	r.register(newRoute)
+	r.core.routes = append(r.core.routes, newRoute)
+
+	return &Route{route: newRoute}
+}
+
+// mount registers a handler of the given kind for a method-less ServeMux pattern.
+func (r *Router) mount(kind RouteKind, pattern string, handler http.Handler) *route {
+	m := &route{
+		kind:    kind,
+		path:    pattern,
+		handler: handler,
+		router:  r,
+	}
+
+	r.register(m)
+	r.core.mounts = append(r.core.mounts, m)
+
+	return m
+}
+
+// register registers the route in the ServeMux serving the host of the router.
+func (r *Router) register(rt *route) {
+	rt.host = r.host
+	rt.mux = r.core.mux
+
+	pattern := r.host + rt.path
+	if r.hostMux != nil {
+		rt.mux = r.hostMux.mux
+		pattern = rt.path
+	}
+	if rt.method != "" {
+		pattern = rt.method + " " + pattern
+	}
+
+	rt.pattern = pattern
+
+	// Mounted handlers answer the requests with an unsupported method themselves.
+	var handler http.Handler = rt
+	if rt.method == "" && rt.kind != KindMount {
+		handler = &unmatched{core: r.core, mux: rt.mux, handler: rt}
 	}
 
-	r.routes = append(r.routes, newRoute)
+	handlePattern(rt.mux, pattern, handler)
+}
+
+// handlePattern registers the handler for the pattern in the mux. It panics with an error
+// wrapping ErrInvalidPattern if the pattern is invalid or conflicts with another one.
+func handlePattern(mux *http.ServeMux, pattern string, handler http.Handler) {
+	defer func() {
+		if v := recover(); v != nil {
+			panic(fmt.Errorf("%w: %v", ErrInvalidPattern, v))
+		}
+	}()
+	mux.Handle(pattern, handler)
+}
+
+// mustNotServe panics if the router has started serving requests.
+func (r *Router) mustNotServe() {
+	if r.core.serving.Load() {
+		panic("goexpress: routes and middlewares cannot be registered after the router has started serving requests")
+	}
+}
+
+// inheritedMiddlewares returns the middlewares of the router and its enclosing routers,
+// starting with the outermost.
+func (r *Router) inheritedMiddlewares() []Middleware {
+	if r.parent == nil {
+		return slices.Clone(r.middlewares)
+	}
+	return slices.Concat(r.parent.inheritedMiddlewares(), r.middlewares)
+}
+
+// build composes the middleware chains of the registered handlers and indexes the routes
+// of each mux. It is called once, before the first request is served.
+func (c *routerCore) build() {
+	c.serving.Store(true)
+
+	for _, rt := range c.routes {
+		rt.build()
+	}
+	for _, m := range c.mounts {
+		m.build()
+	}
+	c.methodNotAllowed.build()
+	c.defaultNotFound.build()
+
+	c.indexes = make(map[*http.ServeMux]*muxIndex, len(c.hosts)+1)
+	c.index(c.mux)
+	for _, h := range c.hosts {
+		c.index(h.mux)
+	}
+}
+
+// index indexes the routes registered in the mux, and registers the fallback handler for the
+// requests that match no pattern unless a handler is mounted at the root.
+func (c *routerCore) index(mux *http.ServeMux) {
+	idx := &muxIndex{patterns: make(map[string]*route)}
+	for _, rt := range c.routes {
+		if rt.mux != mux {
+			continue
+		}
+
+		idx.patterns[rt.pattern] = rt
+		if !slices.Contains(idx.methods, rt.method) {
+			idx.methods = append(idx.methods, rt.method)
+		}
+	}
+	if slices.Contains(idx.methods, http.MethodGet) && !slices.Contains(idx.methods, http.MethodHead) {
+		idx.methods = append(idx.methods, http.MethodHead)
+	}
+	slices.Sort(idx.methods)
+
+	if !slices.ContainsFunc(c.mounts, func(m *route) bool { return m.mux == mux && m.pattern == "/" }) {
+		idx.fallback = &unmatched{core: c, mux: mux}
+		mux.Handle("/", idx.fallback)
+	}
+
+	c.indexes[mux] = idx
+}
+
+// notFound returns the NotFound handler for a request matched by the route, i.e. the handler
+// registered with NotFound whose prefix is the longest prefix of the request path.
+// The http.NotFound handler wrapped with the global middlewares is returned when there is none.
+func (c *routerCore) notFound(rt *route, req *http.Request) http.Handler {
+	var found *route
+	for _, m := range c.mounts {
+		if m.kind == KindNotFound && m.mux == rt.mux && (m.host == "" || m.host == rt.host) &&
+			strings.HasPrefix(req.URL.Path, m.path) && (found == nil || len(m.path) > len(found.path)) {
+			found = m
+		}
+	}
+
+	if found == nil {
+		return c.defaultNotFound.final
+	}
+	return found
+}
+
+// methodNotAllowed responds with a plain-text 405 (Method Not Allowed) status.
+func methodNotAllowed(w http.ResponseWriter, _ *http.Request) {
+	const status = http.StatusMethodNotAllowed
+	http.Error(w, http.StatusText(status), status)
+}
+
+// allowedMethods returns the sorted methods of the registered routes that match the path
+// of the request and whose constraints it satisfies, along with the matching route with the
+// most specific path.
+func (c *routerCore) allowedMethods(mux *http.ServeMux, req *http.Request) ([]string, *route) {
+	idx := c.indexes[mux]
+
+	var (
+		allowed []string
+		matched *route
+	)
+	probe := *req
+	escapedPath := req.URL.EscapedPath()
+	for _, method := range idx.methods {
+		probe.Method = method
+		_, pattern := mux.Handler(&probe)
+		rt := idx.patterns[pattern]
+		if rt == nil || !rt.satisfies(escapedPath) {
+			continue
+		}
+
+		if matched == nil || len(rt.path) > len(matched.path) {
+			matched = rt
+		}
+		allowed = append(allowed, method)
+	}
+
+	return allowed, matched
 }
 
 // wrap applies a series of middlewares to an http.Handler in reverse order,
 // so that the first middleware is the outermost wrapper around the handler.
-func (r *Router) wrap(handler http.Handler, middlewares []Middleware) http.Handler {
+func wrap(handler http.Handler, middlewares []Middleware) http.Handler {
 	finalHandler := handler
 	for i := len(middlewares) - 1; i >= 0; i-- {
 		finalHandler = middlewares[i](finalHandler)
@@ -185,16 +576,101 @@ func (r *Router) wrap(handler http.Handler, middlewares []Middleware) http.Handl
 	return finalHandler
 }
 
+// Route is a handle to a registered route, returned by the route registration methods
+// of the Router to configure the route further.
+type Route struct {
+	route *route
+}
+
+// Name sets the name of the route, used to generate its URL with Router.URL.
+// It panics if the name is empty or already used by another route.
+func (rt *Route) Name(name string) *Route {
+	r := rt.route.router
+	r.mustNotServe()
+
+	if name == "" {
+		panic("goexpress: route name must not be empty")
+	}
+
+	c := r.core
+	if _, exists := c.names[name]; exists {
+		panic(fmt.Sprintf("goexpress: route name %q is already used", name))
+	}
+	if c.names == nil {
+		c.names = make(map[string]*route)
+	}
+
+	c.names[name] = rt.route
+	rt.route.name = name
+
+	return rt
+}
+
+// Meta sets the metadata value of the route for the given key, such as an authorization
+// scope or a deprecation flag. The metadata of the matched route can be read at request time
+// with RouteMeta, including in the middlewares registered with Router.Use.
+func (rt *Route) Meta(key string, value any) *Route {
+	rt.route.router.mustNotServe()
+
+	if rt.route.metadata == nil {
+		rt.route.metadata = make(map[string]any)
+	}
+	rt.route.metadata[key] = value
+
+	return rt
+}
+
 // route describes a registered route, including its HTTP method, path pattern,
 // the name of the associated handler and the applied middlewares.
+//
+// A route is registered in the ServeMux as the handler of its pattern. It serves requests
+// through the handler wrapped with its middleware chain, which is composed by build.
 type route struct {
-	method, path string       // HTTP method and Path
-	handler      http.Handler // handler
-	middlewares  []Middleware // route-specific middlewares
+	kind         RouteKind              // kind of the registered handler
+	method, path string                 // HTTP method and Path
+	host         string                 // host pattern, empty for any host
+	pattern      string                 // pattern registered in the ServeMux
+	mux          *http.ServeMux         // ServeMux serving the route
+	constraints  map[string]*constraint // constraints of the path parameters by name
+	metadata     map[string]any         // metadata of the route by key
+	cachedInfo   RouteInfo              // description of the route, cached when the route is built
+	name         string                 // optional name used for URL generation
+	handler      http.Handler           // handler
+	desc         string                 // description of the handler, used instead of its function name
+	sub          *Router                // router attached with Mount
+	middlewares  []Middleware           // route-specific middlewares
+	router       *Router                // router or route group that registered the route
+	inherited    []Middleware           // middlewares of the router and its enclosing routers
+	final        http.Handler           // handler wrapped with all the middlewares
+	options      http.Handler           // automatic OPTIONS response wrapped with the inherited middlewares
+}
+
+// build composes the middleware chain of the route.
+func (r *route) build() {
+	r.inherited = r.router.inheritedMiddlewares()
+	r.final = wrap(wrap(r.handler, r.middlewares), r.inherited)
+	r.cachedInfo = r.info()
+	if r.kind == KindRoute && r.router.core.autoOptions {
+		r.options = wrap(http.HandlerFunc(noContent), r.inherited)
+	}
+}
+
+// ServeHTTP serves the request through the middleware chain of the route, after storing the
+// route in the request context. Requests whose path parameters do not satisfy the constraints
+// of the route are answered as not found.
+func (r *route) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	for name, c := range r.constraints {
+		if !c.match(req.PathValue(name)) {
+			r.router.core.notFound(r, req).ServeHTTP(w, req)
+			return
+		}
+	}
+
+	r.final.ServeHTTP(w, withRoute(req, r))
 }
 
 // String returns a string representation of the registered route.
-func (r route) String() string {
+func (r *route) String() string {
 	return fmt.Sprintf("%s %s %s %s", r.method, r.path, handlerName(r.handler), middlewareNames(r.middlewares))
 }
 
@@ -223,7 +699,7 @@ func trimRepoName(fn string) string {
 }
 
 func middlewareNames(mws []Middleware) []string {
-	names := make([]string, len(mws))
+	names := make([]string, 0, len(mws))
 	for _, mw := range mws {
 		fullFuncName := runtime.FuncForPC(reflect.ValueOf(mw).Pointer()).Name()
 		name := trimRepoName(fullFuncName)
@@ -232,6 +708,19 @@ func middlewareNames(mws []Middleware) []string {
 	return names
 }
 
+// noContent responds with a 204 (No Content) status.
+func noContent(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// subtreePattern returns the ServeMux pattern matching all the paths under the given path.
+func subtreePattern(p string) string {
+	if !strings.HasSuffix(p, "/") {
+		p += "/"
+	}
+	return p
+}
+
 func normalizePath(p string) string {
 	if p == "" {
 		return "/"
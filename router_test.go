@@ -5,7 +5,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"slices"
 	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/ferdiebergado/goexpress"
@@ -275,6 +277,666 @@ func TestNotFound(t *testing.T) {
 	assertHeader(t, rec, header, wantHeader)
 }
 
+func TestGroupNotFoundAndStatic(t *testing.T) {
+	t.Parallel()
+
+	const header = "X-Middleware"
+
+	grpMw := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(header, "api")
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	r := goexpress.New()
+	r.Get("/hello", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("hello"))
+	}))
+	r.NotFound(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "<h1>Not Found</h1>", http.StatusNotFound)
+	}))
+	r.Group("/api", func(api *goexpress.Router) {
+		api.Static("/docs", "./static")
+		api.NotFound(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, `{"error":"not found"}`, http.StatusNotFound)
+		}))
+	}, grpMw)
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantBody   string
+		wantHeader string
+	}{
+		{
+			name:       "top-level not found",
+			path:       "/unknown",
+			wantStatus: http.StatusNotFound,
+			wantBody:   "<h1>Not Found</h1>",
+		},
+		{
+			name:       "group not found",
+			path:       "/api/unknown",
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"error":"not found"}`,
+			wantHeader: "api",
+		},
+		{
+			name:       "group prefix not found",
+			path:       "/api",
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"error":"not found"}`,
+			wantHeader: "api",
+		},
+		{
+			name:       "group static files",
+			path:       "/api/docs/test.html",
+			wantStatus: http.StatusOK,
+			wantBody:   "<h1>This is a test page</h1>",
+			wantHeader: "api",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, http.NoBody)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assertStatus(t, rec.Code, tt.wantStatus)
+			assertBody(t, rec.Body.String(), tt.wantBody)
+			assertHeader(t, rec, header, tt.wantHeader)
+		})
+	}
+}
+
+func TestMount(t *testing.T) {
+	t.Parallel()
+
+	const header = "X-Middleware"
+
+	globalMw := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(header, "global")
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	users := goexpress.New()
+	users.Get("/{id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("user " + r.PathValue("id") + " at " + r.URL.Path))
+	})).Name("user")
+	users.Post("/{id}/follow", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("follow " + r.PathValue("id")))
+	}))
+
+	r := goexpress.New()
+	r.Use(globalMw)
+	r.Group("/api", func(api *goexpress.Router) {
+		api.Mount("/users", users)
+	})
+	r.Get("/api/users/{id}/follow", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("followers " + r.PathValue("id")))
+	}))
+	r.Mount("/legacy", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("legacy " + r.URL.Path))
+	}))
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "mounted router",
+			method:     http.MethodGet,
+			path:       "/api/users/42",
+			wantStatus: http.StatusOK,
+			wantBody:   "user 42 at /42",
+		},
+		{
+			name:       "mounted router method not allowed",
+			method:     http.MethodPost,
+			path:       "/api/users/42",
+			wantStatus: http.StatusMethodNotAllowed,
+			wantBody:   "Method Not Allowed",
+		},
+		{
+			name:       "parent route overlapping the mount",
+			method:     http.MethodGet,
+			path:       "/api/users/42/follow",
+			wantStatus: http.StatusOK,
+			wantBody:   "followers 42",
+		},
+		{
+			name:       "mounted route with a method of the parent path",
+			method:     http.MethodPost,
+			path:       "/api/users/42/follow",
+			wantStatus: http.StatusOK,
+			wantBody:   "follow 42",
+		},
+		{
+			name:       "mounted handler",
+			method:     http.MethodPost,
+			path:       "/legacy/reports/1",
+			wantStatus: http.StatusOK,
+			wantBody:   "legacy /reports/1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(tt.method, tt.path, http.NoBody)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assertStatus(t, rec.Code, tt.wantStatus)
+			assertBody(t, rec.Body.String(), tt.wantBody)
+			assertHeader(t, rec, header, "global")
+		})
+	}
+
+	routes := r.Routes()
+	if len(routes) != 4 {
+		t.Fatalf("len(r.Routes()) = %d, want: 4", len(routes))
+	}
+	if got, want := routes[1].Pattern, "/api/users/{id}"; got != want {
+		t.Errorf("routes[1].Pattern = %q, want: %q", got, want)
+	}
+	if got, want := len(routes[1].GroupMiddlewares), 1; got != want {
+		t.Errorf("len(routes[1].GroupMiddlewares) = %d, want: %d", got, want)
+	}
+	if got, want := routes[3].Kind, goexpress.KindMount; got != want {
+		t.Errorf("routes[3].Kind = %q, want: %q", got, want)
+	}
+
+	url, err := r.URL("user", "id", "7")
+	if err != nil {
+		t.Fatalf("r.URL() error = %v", err)
+	}
+	if want := "/api/users/7"; url != want {
+		t.Errorf("r.URL() = %q, want: %q", url, want)
+	}
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	t.Parallel()
+
+	const (
+		header     = "X-Middleware"
+		wantHeader = "global"
+		wantStatus = http.StatusMethodNotAllowed
+	)
+
+	mw := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(header, wantHeader)
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("ok"))
+	})
+
+	tests := []struct {
+		name       string
+		path       string
+		setup      func(*goexpress.Router)
+		wantBody   string
+		wantAllow  string
+		wantHeader string
+	}{
+		{
+			name: "default handler",
+			path: "/users/1",
+			setup: func(r *goexpress.Router) {
+				r.Get("/users/{id}", handler)
+				r.Delete("/users/{id}", handler)
+			},
+			wantBody:  "Method Not Allowed",
+			wantAllow: "DELETE, GET, HEAD",
+		},
+		{
+			name: "default handler with global middleware",
+			path: "/users/1",
+			setup: func(r *goexpress.Router) {
+				r.Use(mw)
+				r.Get("/users/{id}", handler)
+			},
+			wantBody:   "Method Not Allowed",
+			wantAllow:  "GET, HEAD",
+			wantHeader: wantHeader,
+		},
+		{
+			name: "custom handler with global middleware",
+			path: "/users/1",
+			setup: func(r *goexpress.Router) {
+				r.Use(mw)
+				r.Put("/users/{id}", handler)
+				r.MethodNotAllowed(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					http.Error(w, "Custom 405", wantStatus)
+				}))
+			},
+			wantBody:   "Custom 405",
+			wantAllow:  "PUT",
+			wantHeader: wantHeader,
+		},
+		{
+			name: "custom not found handler",
+			path: "/users/1",
+			setup: func(r *goexpress.Router) {
+				r.Get("/users/{id}", handler)
+				r.NotFound(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					http.Error(w, "Custom 404", http.StatusNotFound)
+				}))
+			},
+			wantBody:  "Method Not Allowed",
+			wantAllow: "GET, HEAD",
+		},
+		{
+			name: "route group",
+			path: "/api/users/1",
+			setup: func(r *goexpress.Router) {
+				r.Group("/api", func(api *goexpress.Router) {
+					api.Patch("/users/{id}", handler)
+				})
+			},
+			wantBody:  "Method Not Allowed",
+			wantAllow: "PATCH",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := goexpress.New()
+			tt.setup(r)
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, http.NoBody)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assertStatus(t, rec.Code, wantStatus)
+			assertBody(t, rec.Body.String(), tt.wantBody)
+			assertHeader(t, rec, "Allow", tt.wantAllow)
+			assertHeader(t, rec, header, tt.wantHeader)
+		})
+	}
+}
+
+func TestMethodNotAllowedInGroup(t *testing.T) {
+	t.Parallel()
+
+	r := goexpress.New()
+	r.Group("/api", func(api *goexpress.Router) {
+		defer func() {
+			if err := recover(); err == nil {
+				t.Error("MethodNotAllowed in a route group did not panic")
+			}
+		}()
+
+		api.MethodNotAllowed(http.NotFoundHandler())
+	})
+}
+
+func TestMatchAndAny(t *testing.T) {
+	t.Parallel()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Method))
+	})
+
+	r := goexpress.New()
+	if got := len(r.Match([]string{"put", http.MethodPatch, "PUT"}, "/users/{id}", handler)); got != 2 {
+		t.Errorf("len(r.Match()) = %d, want: 2", got)
+	}
+	r.Any("/echo", handler)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+		wantBody   string
+		wantAllow  string
+	}{
+		{
+			name:       "matched lowercase method",
+			method:     http.MethodPut,
+			path:       "/users/1",
+			wantStatus: http.StatusOK,
+			wantBody:   http.MethodPut,
+		},
+		{
+			name:       "matched method",
+			method:     http.MethodPatch,
+			path:       "/users/1",
+			wantStatus: http.StatusOK,
+			wantBody:   http.MethodPatch,
+		},
+		{
+			name:       "unmatched method",
+			method:     http.MethodGet,
+			path:       "/users/1",
+			wantStatus: http.StatusMethodNotAllowed,
+			wantBody:   "Method Not Allowed",
+			wantAllow:  "PATCH, PUT",
+		},
+		{
+			name:       "any",
+			method:     http.MethodDelete,
+			path:       "/echo",
+			wantStatus: http.StatusOK,
+			wantBody:   http.MethodDelete,
+		},
+		{
+			name:       "any options",
+			method:     http.MethodOptions,
+			path:       "/echo",
+			wantStatus: http.StatusOK,
+			wantBody:   http.MethodOptions,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(tt.method, tt.path, http.NoBody)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assertStatus(t, rec.Code, tt.wantStatus)
+			assertBody(t, rec.Body.String(), tt.wantBody)
+			assertHeader(t, rec, "Allow", tt.wantAllow)
+		})
+	}
+
+	var methods []string
+	for _, ri := range r.Routes() {
+		methods = append(methods, ri.Method)
+	}
+	want := []string{"PUT", "PATCH", "GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "CONNECT", "OPTIONS", "TRACE"}
+	if !slices.Equal(methods, want) {
+		t.Errorf("route methods = %v, want: %v", methods, want)
+	}
+}
+
+func TestAutoOptions(t *testing.T) {
+	t.Parallel()
+
+	const header = "X-Middleware"
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Method))
+	})
+
+	grpMw := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(header, "group")
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		enabled    bool
+		setup      func(*goexpress.Router)
+		wantStatus int
+		wantBody   string
+		wantAllow  string
+		wantHeader string
+	}{
+		{
+			name:    "automatic response",
+			path:    "/todos/1",
+			enabled: true,
+			setup: func(r *goexpress.Router) {
+				r.Get("/todos/{id}", handler)
+				r.Put("/todos/{id}", handler)
+			},
+			wantStatus: http.StatusNoContent,
+			wantAllow:  "GET, HEAD, OPTIONS, PUT",
+		},
+		{
+			name:    "explicit options route",
+			path:    "/todos/1",
+			enabled: true,
+			setup: func(r *goexpress.Router) {
+				r.Get("/todos/{id}", handler)
+				r.Options("/todos/{id}", handler)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   http.MethodOptions,
+		},
+		{
+			name:    "group middleware",
+			path:    "/api/todos",
+			enabled: true,
+			setup: func(r *goexpress.Router) {
+				r.Group("/api", func(api *goexpress.Router) {
+					api.Post("/todos", handler)
+				}, grpMw)
+			},
+			wantStatus: http.StatusNoContent,
+			wantAllow:  "OPTIONS, POST",
+			wantHeader: "group",
+		},
+		{
+			name:    "unregistered path",
+			path:    "/unknown",
+			enabled: true,
+			setup: func(r *goexpress.Router) {
+				r.Get("/todos/{id}", handler)
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   "404 page not found",
+		},
+		{
+			name: "disabled",
+			path: "/todos/1",
+			setup: func(r *goexpress.Router) {
+				r.Get("/todos/{id}", handler)
+			},
+			wantStatus: http.StatusMethodNotAllowed,
+			wantBody:   "Method Not Allowed",
+			wantAllow:  "GET, HEAD",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := goexpress.New()
+			r.AutoOptions(tt.enabled)
+			tt.setup(r)
+
+			req := httptest.NewRequest(http.MethodOptions, tt.path, http.NoBody)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assertStatus(t, rec.Code, tt.wantStatus)
+			assertBody(t, rec.Body.String(), tt.wantBody)
+			assertHeader(t, rec, "Allow", tt.wantAllow)
+			assertHeader(t, rec, header, tt.wantHeader)
+		})
+	}
+}
+
+func TestAutoOptionsComposedOnce(t *testing.T) {
+	t.Parallel()
+
+	var composed atomic.Int32
+	mw := func(next http.Handler) http.Handler {
+		composed.Add(1)
+		return next
+	}
+
+	r := goexpress.New()
+	r.AutoOptions(true)
+	r.Use(mw)
+	r.Put("/todos/{id}", http.NotFoundHandler())
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPut, "/todos/1", http.NoBody))
+	want := composed.Load()
+
+	for range 3 {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodOptions, "/todos/1", http.NoBody))
+		assertStatus(t, rec.Code, http.StatusNoContent)
+	}
+
+	if got := composed.Load(); got != want {
+		t.Errorf("middleware composed %d times after OPTIONS requests, want: %d", got, want)
+	}
+}
+func TestUseAfterRegistration(t *testing.T) {
+	t.Parallel()
+
+	const header = "X-Middleware"
+
+	headerMw := func(value string) goexpress.Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Add(header, value)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("ok"))
+	})
+
+	r := goexpress.New()
+	r.Get("/hello", handler)
+	r.Static("/static", "./static")
+	r.NotFound(http.HandlerFunc(http.NotFound))
+	r.Group("/api", func(api *goexpress.Router) {
+		api.Get("/hello", handler)
+		api.Use(headerMw("group"))
+	})
+	r.Use(headerMw("global"))
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantHeader []string
+	}{
+		{
+			name:       "route",
+			path:       "/hello",
+			wantStatus: http.StatusOK,
+			wantHeader: []string{"global"},
+		},
+		{
+			name:       "static files",
+			path:       "/static/test.html",
+			wantStatus: http.StatusOK,
+			wantHeader: []string{"global"},
+		},
+		{
+			name:       "not found",
+			path:       "/unknown",
+			wantStatus: http.StatusNotFound,
+			wantHeader: []string{"global"},
+		},
+		{
+			name:       "route group",
+			path:       "/api/hello",
+			wantStatus: http.StatusOK,
+			wantHeader: []string{"global", "group"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, http.NoBody)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assertStatus(t, rec.Code, tt.wantStatus)
+
+			if got := rec.Header().Values(header); !slices.Equal(got, tt.wantHeader) {
+				t.Errorf("rec.Header().Values(%q) = %q, want: %q", header, got, tt.wantHeader)
+			}
+		})
+	}
+}
+
+func TestRegisterAfterServe(t *testing.T) {
+	t.Parallel()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("ok"))
+	})
+
+	tests := []struct {
+		name     string
+		register func(*goexpress.Router)
+	}{
+		{
+			name: "route",
+			register: func(r *goexpress.Router) {
+				r.Post("/hello", handler)
+			},
+		},
+		{
+			name: "middleware",
+			register: func(r *goexpress.Router) {
+				r.Use(goexpress.LogRequest)
+			},
+		},
+		{
+			name: "route group",
+			register: func(r *goexpress.Router) {
+				r.Group("/api", func(*goexpress.Router) {})
+			},
+		},
+		{
+			name: "not found handler",
+			register: func(r *goexpress.Router) {
+				r.NotFound(handler)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := goexpress.New()
+			r.Get("/hello", handler)
+
+			req := httptest.NewRequest(http.MethodGet, "/hello", http.NoBody)
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			defer func() {
+				if err := recover(); err == nil {
+					t.Error("registration after serving did not panic")
+				}
+			}()
+
+			tt.register(r)
+		})
+	}
+}
+
 func TestRouter(t *testing.T) {
 	t.Parallel()
 
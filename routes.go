@@ -0,0 +1,172 @@
+package goexpress
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// RouteKind identifies the kind of a registered handler.
+type RouteKind string
+
+// Kinds of registered handlers.
+const (
+	KindRoute    RouteKind = "route"     // route registered for a method, e.g. with Router.Get
+	KindStatic   RouteKind = "static"    // static files served with Router.Static
+	KindNotFound RouteKind = "not_found" // handler registered with Router.NotFound
+	KindMount    RouteKind = "mount"     // handler attached with Router.Mount
+)
+
+// RouteFormat is the output format of Router.WriteRoutes.
+type RouteFormat int
+
+// Output formats of Router.WriteRoutes.
+const (
+	RouteFormatText     RouteFormat = iota // aligned columns, e.g. for startup logs
+	RouteFormatJSON                        // indented JSON array of RouteInfo
+	RouteFormatMarkdown                    // Markdown table, e.g. for documentation
+)
+
+// RouteInfo describes a registered route.
+type RouteInfo struct {
+	Kind             RouteKind      `json:"kind"`               // kind of the registered handler
+	Method           string         `json:"method,omitempty"`   // HTTP method, empty for static files and NotFound
+	Host             string         `json:"host,omitempty"`     // host pattern, empty for any host
+	Pattern          string         `json:"pattern"`            // path pattern including the route group prefixes
+	Name             string         `json:"name,omitempty"`     // name of the route, empty if unnamed
+	Handler          string         `json:"handler"`            // name of the function implementing the handler
+	Middlewares      []string       `json:"middlewares"`        // names of the route-specific middlewares
+	GroupMiddlewares []string       `json:"group_middlewares"`  // names of the middlewares of the route groups and the router
+	Metadata         map[string]any `json:"metadata,omitempty"` // metadata set with Route.Meta
+}
+
+// label returns the route label of the logs and metrics: the pattern, prefixed by the host
+// pattern for the routes of a host, e.g. api.example.com/users/{id}.
+func (ri RouteInfo) label() string {
+	return ri.Host + ri.Pattern
+}
+
+// Routes returns the descriptions of the registered routes in the order of registration,
+// followed by the static file handlers, mounted handlers and NotFound handlers.
+// The routes of a mounted *Router are listed in place of the router, with the mount prefix
+// and, unless they have their own, the host of the mount.
+func (r *Router) Routes() []RouteInfo {
+	c := r.core
+	routes := make([]RouteInfo, 0, len(c.routes)+len(c.mounts))
+	for _, rt := range c.routes {
+		routes = append(routes, rt.info())
+	}
+	for _, m := range c.mounts {
+		if m.sub == nil {
+			routes = append(routes, m.info())
+			continue
+		}
+
+		prefix := strings.TrimSuffix(m.path, "/")
+		inherited := middlewareNames(m.router.inheritedMiddlewares())
+		for _, ri := range m.sub.Routes() {
+			ri.Pattern = prefix + ri.Pattern
+			if ri.Host == "" {
+				ri.Host = m.host
+			}
+			ri.GroupMiddlewares = slices.Concat(inherited, ri.GroupMiddlewares)
+			routes = append(routes, ri)
+		}
+	}
+	return routes
+}
+
+// WriteRoutes writes the table of the registered routes to w in the given format.
+func (r *Router) WriteRoutes(w io.Writer, format RouteFormat) error {
+	routes := r.Routes()
+
+	var err error
+	switch format {
+	case RouteFormatText:
+		err = writeRoutesText(w, routes)
+	case RouteFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(routes)
+	case RouteFormatMarkdown:
+		err = writeRoutesMarkdown(w, routes)
+	default:
+		return fmt.Errorf("goexpress: unknown route format %d", format)
+	}
+
+	if err != nil {
+		return fmt.Errorf("goexpress: write routes: %w", err)
+	}
+	return nil
+}
+
+// routeColumns are the column headers of the text and Markdown route tables.
+var routeColumns = []string{"METHOD", "PATTERN", "KIND", "NAME", "HANDLER", "MIDDLEWARES"}
+
+// cells returns the values of the route in the text and Markdown route tables.
+func (ri RouteInfo) cells() []string {
+	middlewares := slices.Concat(ri.GroupMiddlewares, ri.Middlewares)
+	cells := []string{ri.Method, ri.label(), string(ri.Kind), ri.Name, ri.Handler, strings.Join(middlewares, ", ")}
+	for i, cell := range cells {
+		if cell == "" {
+			cells[i] = "-"
+		}
+	}
+	return cells
+}
+
+// writeRoutesText writes the routes as aligned columns.
+func writeRoutesText(w io.Writer, routes []RouteInfo) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, strings.Join(routeColumns, "\t")); err != nil {
+		return err //nolint:wrapcheck // wrapped by WriteRoutes
+	}
+	for _, ri := range routes {
+		if _, err := fmt.Fprintln(tw, strings.Join(ri.cells(), "\t")); err != nil {
+			return err //nolint:wrapcheck // wrapped by WriteRoutes
+		}
+	}
+	return tw.Flush() //nolint:wrapcheck // wrapped by WriteRoutes
+}
+
+// writeRoutesMarkdown writes the routes as a Markdown table.
+func writeRoutesMarkdown(w io.Writer, routes []RouteInfo) error {
+	var b strings.Builder
+
+	b.WriteString("| " + strings.Join(routeColumns, " | ") + " |\n")
+	b.WriteString("|" + strings.Repeat(" --- |", len(routeColumns)) + "\n")
+	for _, ri := range routes {
+		cells := ri.cells()
+		for i, cell := range cells {
+			cells[i] = strings.ReplaceAll(cell, "|", `\|`)
+		}
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err //nolint:wrapcheck // wrapped by WriteRoutes
+}
+
+// info returns the description of the route.
+func (r *route) info() RouteInfo {
+	handler := r.desc
+	if handler == "" {
+		handler = handlerName(r.handler)
+	}
+
+	return RouteInfo{
+		Kind:             r.kind,
+		Method:           r.method,
+		Host:             r.host,
+		Pattern:          r.path,
+		Name:             r.name,
+		Handler:          handler,
+		Middlewares:      middlewareNames(r.middlewares),
+		GroupMiddlewares: middlewareNames(r.router.inheritedMiddlewares()),
+		Metadata:         maps.Clone(r.metadata),
+	}
+}
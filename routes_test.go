@@ -0,0 +1,268 @@
+package goexpress_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ferdiebergado/goexpress"
+)
+
+func listUsers(w http.ResponseWriter, _ *http.Request) {
+	w.Write([]byte("users"))
+}
+
+func showUser(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte("user " + r.PathValue("id")))
+}
+
+func authMw(next http.Handler) http.Handler {
+	return next
+}
+
+func auditMw(next http.Handler) http.Handler {
+	return next
+}
+
+func cacheMw(next http.Handler) http.Handler {
+	return next
+}
+
+func TestRoutes(t *testing.T) {
+	t.Parallel()
+
+	r := goexpress.New()
+	r.Get("/users", http.HandlerFunc(listUsers), cacheMw).Name("users")
+	r.Group("/admin", func(admin *goexpress.Router) {
+		admin.Get("/users/{id}", http.HandlerFunc(showUser))
+	}, authMw)
+	r.Use(auditMw)
+
+	want := []goexpress.RouteInfo{
+		{
+			Kind:             goexpress.KindRoute,
+			Method:           http.MethodGet,
+			Pattern:          "/users",
+			Name:             "users",
+			Handler:          "goexpress_test.listUsers",
+			Middlewares:      []string{"goexpress_test.cacheMw"},
+			GroupMiddlewares: []string{"goexpress_test.auditMw"},
+		},
+		{
+			Kind:             goexpress.KindRoute,
+			Method:           http.MethodGet,
+			Pattern:          "/admin/users/{id}",
+			Handler:          "goexpress_test.showUser",
+			Middlewares:      []string{},
+			GroupMiddlewares: []string{"goexpress_test.auditMw", "goexpress_test.authMw"},
+		},
+	}
+
+	if got := r.Routes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("r.Routes() = %+v, want: %+v", got, want)
+	}
+}
+
+func TestWriteRoutes(t *testing.T) {
+	t.Parallel()
+
+	r := goexpress.New()
+	r.Use(auditMw)
+	r.Get("/users", http.HandlerFunc(listUsers), cacheMw).Name("users")
+	r.Static("/assets", "./static")
+	r.NotFound(http.NotFoundHandler())
+
+	tests := []struct {
+		name   string
+		format goexpress.RouteFormat
+		want   string
+	}{
+		{
+			name:   "text",
+			format: goexpress.RouteFormatText,
+			want: `METHOD  PATTERN   KIND       NAME   HANDLER                    MIDDLEWARES
+GET     /users    route      users  goexpress_test.listUsers   goexpress_test.auditMw, goexpress_test.cacheMw
+-       /assets/  static     -      http.FileServer(./static)  goexpress_test.auditMw
+-       /         not_found  -      net/http.NotFound          goexpress_test.auditMw
+`,
+		},
+		{
+			name:   "markdown",
+			format: goexpress.RouteFormatMarkdown,
+			want: `| METHOD | PATTERN | KIND | NAME | HANDLER | MIDDLEWARES |
+| --- | --- | --- | --- | --- | --- |
+| GET | /users | route | users | goexpress_test.listUsers | goexpress_test.auditMw, goexpress_test.cacheMw |
+| - | /assets/ | static | - | http.FileServer(./static) | goexpress_test.auditMw |
+| - | / | not_found | - | net/http.NotFound | goexpress_test.auditMw |
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var b strings.Builder
+			if err := r.WriteRoutes(&b, tt.format); err != nil {
+				t.Fatalf("r.WriteRoutes() error = %v", err)
+			}
+
+			if got := b.String(); got != tt.want {
+				t.Errorf("r.WriteRoutes() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteRoutesJSON(t *testing.T) {
+	t.Parallel()
+
+	r := goexpress.New()
+	r.Get("/users", http.HandlerFunc(listUsers)).Name("users")
+	r.Static("/assets", "./static")
+
+	var b strings.Builder
+	if err := r.WriteRoutes(&b, goexpress.RouteFormatJSON); err != nil {
+		t.Fatalf("r.WriteRoutes() error = %v", err)
+	}
+
+	var got []goexpress.RouteInfo
+	if err := json.Unmarshal([]byte(b.String()), &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	if want := r.Routes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("decoded routes = %+v, want: %+v", got, want)
+	}
+}
+
+func TestWriteRoutesUnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	var b strings.Builder
+	if err := goexpress.New().WriteRoutes(&b, goexpress.RouteFormat(-1)); err == nil {
+		t.Error("r.WriteRoutes() with an unknown format did not return an error")
+	}
+}
+
+func TestRouteMeta(t *testing.T) {
+	t.Parallel()
+
+	const header = "X-Scope"
+
+	// scopeMw reads the metadata of the matched route from a global middleware.
+	scopeMw := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if scope, ok := goexpress.RouteMeta(r.Context(), "scope"); ok {
+				w.Header().Set(header, scope.(string))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	r := goexpress.New()
+	r.Use(scopeMw)
+	r.Get("/users", http.HandlerFunc(listUsers)).Meta("scope", "users:read").Meta("deprecated", true)
+	r.Get("/users/{id}", http.HandlerFunc(showUser))
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantHeader string
+	}{
+		{
+			name:       "route with metadata",
+			path:       "/users",
+			wantStatus: http.StatusOK,
+			wantHeader: "users:read",
+		},
+		{
+			name:       "route without metadata",
+			path:       "/users/1",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "no matched route",
+			path:       "/posts",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, http.NoBody)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assertStatus(t, rec.Code, tt.wantStatus)
+			assertHeader(t, rec, header, tt.wantHeader)
+		})
+	}
+
+	want := map[string]any{"scope": "users:read", "deprecated": true}
+	if got := r.Routes()[0].Metadata; !reflect.DeepEqual(got, want) {
+		t.Errorf("r.Routes()[0].Metadata = %v, want: %v", got, want)
+	}
+}
+
+func TestRouteFromContext(t *testing.T) {
+	t.Parallel()
+
+	const header = "X-Route"
+
+	// routeMw reports the matched route from a global middleware.
+	routeMw := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if ri, ok := goexpress.RouteFromContext(r.Context()); ok {
+				w.Header().Set(header, strings.TrimSpace(ri.Method+" "+ri.Pattern+" "+ri.Name))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	r := goexpress.New()
+	r.Use(routeMw)
+	r.Get("/users", http.HandlerFunc(listUsers))
+	r.Group("/api", func(api *goexpress.Router) {
+		api.Get("/users/:id", http.HandlerFunc(showUser)).Name("user")
+	})
+
+	tests := []struct {
+		name       string
+		path       string
+		wantHeader string
+	}{
+		{
+			name:       "route",
+			path:       "/users",
+			wantHeader: "GET /users",
+		},
+		{
+			name:       "named route in group",
+			path:       "/api/users/42",
+			wantHeader: "GET /api/users/{id} user",
+		},
+		{
+			name: "no matched route",
+			path: "/posts",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, http.NoBody)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assertHeader(t, rec, header, tt.wantHeader)
+		})
+	}
+}
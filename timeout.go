@@ -0,0 +1,217 @@
+package goexpress
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// TimeoutOptions configures the middleware returned by Timeout.
+type TimeoutOptions struct {
+	// Status is the status code of the response to the requests that time out, e.g.
+	// http.StatusGatewayTimeout. http.StatusServiceUnavailable is used when zero.
+	Status int
+
+	// Handler writes the response to the requests that time out, instead of the plain-text
+	// response with Status.
+	Handler http.Handler
+}
+
+// Timeout returns a middleware that limits the time to serve a request to the duration d.
+// The handler runs with a request context whose deadline is d, so that it can observe the
+// cancellation, e.g. through the database queries and outgoing requests using the context.
+//
+// When the deadline expires before the handler has written the response headers, the timeout
+// response is written and the later writes of the handler fail with http.ErrHandlerTimeout.
+// Otherwise, the response is not buffered and the handler keeps streaming it until it returns,
+// so it must stop on its own when the context is done. Hijacking the connection, e.g. for a
+// WebSocket upgrade, counts as writing the response headers.
+//
+// A panic in the handler is propagated to the calling goroutine, unless the timeout response
+// was already written. The middleware can be registered with Use, for a route group, or for
+// a single route; nested timeouts are limited by the shortest one.
+func Timeout(d time.Duration, opts TimeoutOptions) Middleware {
+	timedOut := opts.Handler
+	if timedOut == nil {
+		status := opts.Status
+		if status == 0 {
+			status = http.StatusServiceUnavailable
+		}
+		timedOut = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, http.StatusText(status), status)
+		})
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+			defer cancel()
+			r = r.WithContext(ctx)
+
+			tw := &timeoutWriter{w: w, ctx: ctx, header: make(http.Header)}
+			done := make(chan struct{})
+			panicked := make(chan any, 1)
+
+			go func() {
+				defer func() {
+					if p := recover(); p != nil {
+						panicked <- p
+						return
+					}
+					close(done)
+				}()
+				next.ServeHTTP(tw, r)
+			}()
+
+			select {
+			case <-done:
+				return
+			case p := <-panicked:
+				panic(p)
+			case <-ctx.Done():
+			}
+
+			if tw.timeout() {
+				timedOut.ServeHTTP(w, r)
+				return
+			}
+
+			// The handler has started the response, which only it can complete.
+			select {
+			case <-done:
+			case p := <-panicked:
+				panic(p)
+			}
+		})
+	}
+}
+
+// timeoutWriter guards the response writer of a handler run by Timeout, so that the handler
+// and the timeout response do not both write the response.
+type timeoutWriter struct {
+	w      http.ResponseWriter
+	ctx    context.Context // context of the request, done when the request times out
+	header http.Header     // headers set by the handler before writing the response headers
+
+	mu          sync.Mutex
+	wroteHeader bool // whether the handler has written the response headers
+	timedOut    bool // whether the timeout response is written instead
+}
+
+// timeout marks the response as timed out, unless the handler has written the response
+// headers, and reports whether the timeout response must be written.
+func (tw *timeoutWriter) timeout() bool {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.wroteHeader {
+		return false
+	}
+	tw.timedOut = true
+	return true
+}
+
+// Header returns the header map of the response.
+func (tw *timeoutWriter) Header() http.Header {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.wroteHeader {
+		// Trailers are set in the header map of the response after the headers are written.
+		return tw.w.Header()
+	}
+	return tw.header
+}
+
+// WriteHeader writes the response headers, unless the request has timed out.
+func (tw *timeoutWriter) WriteHeader(status int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	tw.writeHeader(status)
+}
+
+// writeHeader copies the headers set by the handler to the response and writes them.
+// It must be called with the lock held.
+func (tw *timeoutWriter) writeHeader(status int) {
+	if tw.wroteHeader {
+		return
+	}
+	// The handler may observe the end of the context before Timeout does.
+	if tw.ctx.Err() != nil {
+		tw.timedOut = true
+	}
+	if tw.timedOut {
+		return
+	}
+
+	dst := tw.w.Header()
+	for k, v := range tw.header {
+		dst[k] = v
+	}
+	// Informational responses, e.g. 103 Early Hints, precede the final response headers.
+	if status >= 200 || status == http.StatusSwitchingProtocols {
+		tw.wroteHeader = true
+	}
+	tw.w.WriteHeader(status)
+}
+
+// Write writes the data to the response, or fails with http.ErrHandlerTimeout if the request
+// has timed out.
+func (tw *timeoutWriter) Write(b []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	tw.writeHeader(http.StatusOK)
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	return tw.w.Write(b) //nolint:wrapcheck // errors are part of the contract
+}
+
+// Flush sends the buffered data to the client, unless the request has timed out.
+func (tw *timeoutWriter) Flush() {
+	tw.FlushError() //nolint:errcheck // http.Flusher cannot report errors
+}
+
+// FlushError sends the buffered data to the client, or fails with http.ErrHandlerTimeout if the
+// request has timed out. It is used by http.ResponseController.
+func (tw *timeoutWriter) FlushError() error {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	tw.writeHeader(http.StatusOK)
+	if tw.timedOut {
+		return http.ErrHandlerTimeout
+	}
+	return http.NewResponseController(tw.w).Flush() //nolint:wrapcheck // errors are part of the contract
+}
+
+// Hijack lets the handler take over the connection, unless the request has timed out.
+// The response is then considered written, so that the timeout response is not sent.
+func (tw *timeoutWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.ctx.Err() != nil {
+		tw.timedOut = true
+	}
+	if tw.timedOut {
+		return nil, nil, http.ErrHandlerTimeout
+	}
+
+	conn, brw, err := http.NewResponseController(tw.w).Hijack()
+	if err == nil {
+		tw.wroteHeader = true
+	}
+	return conn, brw, err //nolint:wrapcheck // errors are part of the contract
+}
+
+// Unwrap returns the wrapped http.ResponseWriter for use by http.ResponseController, e.g. to
+// set the write deadline of the response.
+func (tw *timeoutWriter) Unwrap() http.ResponseWriter {
+	return tw.w
+}
@@ -0,0 +1,182 @@
+package goexpress_test
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ferdiebergado/goexpress"
+)
+
+func TestTimeout(t *testing.T) {
+	t.Parallel()
+
+	const timeout = 20 * time.Millisecond
+
+	writeErrs := make(chan error, 1)
+	slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		w.Header().Set("X-Slow", "true")
+		_, err := w.Write([]byte("too late"))
+		writeErrs <- err
+	})
+	fast := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Fast", "true")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("fast"))
+	})
+	streaming := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("first "))
+		http.NewResponseController(w).Flush()
+		<-r.Context().Done()
+		w.Write([]byte("last"))
+	})
+
+	r := goexpress.New()
+	r.Get("/slow", slow, goexpress.Timeout(timeout, goexpress.TimeoutOptions{}))
+	r.Get("/fast", fast, goexpress.Timeout(timeout, goexpress.TimeoutOptions{}))
+	r.Get("/stream", streaming, goexpress.Timeout(timeout, goexpress.TimeoutOptions{}))
+	r.Group("/api", func(api *goexpress.Router) {
+		api.Get("/slow", slow)
+		api.Get("/custom", slow, goexpress.Timeout(timeout/2, goexpress.TimeoutOptions{
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusGatewayTimeout)
+				w.Write([]byte(`{"error":"timeout"}`))
+			}),
+		}))
+	}, goexpress.Timeout(timeout, goexpress.TimeoutOptions{Status: http.StatusGatewayTimeout}))
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantBody   string
+		wantHeader string
+		wantErr    bool // whether the handler writes after the timeout
+	}{
+		{
+			name:       "timed out",
+			path:       "/slow",
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody:   "Service Unavailable",
+			wantErr:    true,
+		},
+		{
+			name:       "completed in time",
+			path:       "/fast",
+			wantStatus: http.StatusCreated,
+			wantBody:   "fast",
+		},
+		{
+			name:       "streamed response",
+			path:       "/stream",
+			wantStatus: http.StatusOK,
+			wantBody:   "first last",
+		},
+		{
+			name:       "group timeout with status",
+			path:       "/api/slow",
+			wantStatus: http.StatusGatewayTimeout,
+			wantBody:   "Gateway Timeout",
+			wantErr:    true,
+		},
+		{
+			name:       "custom handler",
+			path:       "/api/custom",
+			wantStatus: http.StatusGatewayTimeout,
+			wantBody:   `{"error":"timeout"}`,
+			wantErr:    true,
+		},
+	}
+
+	// The cases share the channel of the write errors of the slow handler.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, http.NoBody)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assertStatus(t, rec.Code, tt.wantStatus)
+			assertBody(t, rec.Body.String(), tt.wantBody)
+			assertHeader(t, rec, "X-Slow", "")
+
+			if !tt.wantErr {
+				return
+			}
+			if err := <-writeErrs; !errors.Is(err, http.ErrHandlerTimeout) {
+				t.Errorf("Write() error = %v, want: %v", err, http.ErrHandlerTimeout)
+			}
+		})
+	}
+}
+
+func TestTimeoutPanic(t *testing.T) {
+	t.Parallel()
+
+	r := goexpress.New()
+	r.Use(goexpress.NewRecoverer(goexpress.RecovererOptions{
+		Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}))
+	r.Use(goexpress.Timeout(time.Second, goexpress.TimeoutOptions{}))
+	r.Get("/", http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		panic("test panic")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assertStatus(t, rec.Code, http.StatusInternalServerError)
+}
+
+func TestTimeoutHijack(t *testing.T) {
+	t.Parallel()
+
+	const timeout = 20 * time.Millisecond
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rc := http.NewResponseController(w)
+		if err := rc.SetWriteDeadline(time.Now().Add(time.Minute)); err != nil {
+			t.Errorf("SetWriteDeadline() error = %v", err)
+		}
+
+		conn, brw, err := rc.Hijack()
+		if err != nil {
+			t.Errorf("Hijack() error = %v", err)
+			return
+		}
+		defer conn.Close()
+
+		<-r.Context().Done()
+		brw.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: test\r\n\r\n")
+		if err := brw.Flush(); err != nil {
+			t.Errorf("write: %v", err)
+		}
+	})
+	srv := httptest.NewServer(goexpress.Timeout(timeout, goexpress.TimeoutOptions{})(handler))
+	defer srv.Close()
+
+	conn, err := net.Dial("tcp", srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("GET / HTTP/1.1\r\nHost: example.com\r\nConnection: Upgrade\r\nUpgrade: test\r\n\r\n")); err != nil {
+		t.Fatal(err)
+	}
+	res, err := http.ReadResponse(bufio.NewReader(conn), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	assertStatus(t, res.StatusCode, http.StatusSwitchingProtocols)
+}
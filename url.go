@@ -0,0 +1,142 @@
+package goexpress
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+var (
+	// ErrRouteNotFound is returned by Router.URL when no route has the given name.
+	ErrRouteNotFound = errors.New("goexpress: route not found")
+
+	// ErrMissingParam is returned by Router.URL when a wildcard of the route has no value.
+	ErrMissingParam = errors.New("goexpress: missing route parameter")
+
+	// ErrExtraParam is returned by Router.URL when a parameter does not match any wildcard of the route.
+	ErrExtraParam = errors.New("goexpress: unknown route parameter")
+
+	// ErrInvalidParam is returned by Router.URL when the value of a parameter cannot match its wildcard,
+	// e.g. an empty value for a {name} wildcard or a value that does not satisfy its constraint.
+	ErrInvalidParam = errors.New("goexpress: invalid route parameter")
+)
+
+// URL returns the path of the route with the given name, with its wildcards replaced by the
+// given parameters. The parameters are passed as alternating names and values, e.g.
+//
+//	router.Get("/users/{id}/files/{path...}", handler).Name("user-file")
+//	router.URL("user-file", "id", "42", "path", "docs/report 1.pdf") // "/users/42/files/docs/report%201.pdf"
+//
+// Values are escaped as path segments. The slashes in the value of a trailing {name...}
+// wildcard are kept, so that each of its segments is escaped separately.
+// Named routes of the routers attached with Mount are resolved with the mount prefix.
+//
+// Only the path is generated: the host of the routes registered with Router.Host is not
+// included, and the wildcards of a host pattern cannot be given as parameters.
+// An error is returned if the route does not exist, if a parameter is missing or unknown, or if
+// its value is empty, except for a {name...} wildcard, or does not satisfy its constraint.
+func (r *Router) URL(name string, params ...string) (string, error) {
+	rt, ok := r.core.names[name]
+	if !ok {
+		return r.mountedURL(name, params)
+	}
+
+	if len(params)%2 != 0 {
+		return "", fmt.Errorf("goexpress: route %q: odd number of parameters, want name and value pairs", name)
+	}
+
+	values := make(map[string]string, len(params)/2)
+	for i := 0; i < len(params); i += 2 {
+		values[params[i]] = params[i+1]
+	}
+
+	segments := strings.Split(rt.path, "/")
+	used := make([]string, 0, len(values))
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") {
+			continue
+		}
+
+		wildcard := segment[1 : len(segment)-1]
+		if wildcard == "$" {
+			segments[i] = ""
+			continue
+		}
+
+		paramName, multi := strings.CutSuffix(wildcard, "...")
+		value, ok := values[paramName]
+		if !ok {
+			return "", fmt.Errorf("%w: route %q: %q", ErrMissingParam, name, paramName)
+		}
+		used = append(used, paramName)
+
+		if value == "" && !multi {
+			return "", fmt.Errorf("%w: route %q: %q is empty", ErrInvalidParam, name, paramName)
+		}
+		if c, ok := rt.constraints[paramName]; ok && !c.match(value) {
+			return "", fmt.Errorf("%w: route %q: %q does not satisfy constraint %q", ErrInvalidParam, name, paramName, c.name)
+		}
+
+		segments[i] = escapeSegments(value, multi)
+	}
+
+	for paramName := range values {
+		if slices.Contains(used, paramName) {
+			continue
+		}
+		if slices.Contains(hostWildcards(rt.host), paramName) {
+			return "", fmt.Errorf("%w: route %q: %q is a wildcard of the host %q, which URL does not generate",
+				ErrExtraParam, name, paramName, rt.host)
+		}
+		return "", fmt.Errorf("%w: route %q: %q", ErrExtraParam, name, paramName)
+	}
+
+	return strings.Join(segments, "/"), nil
+}
+
+// hostWildcards returns the names of the wildcards of the host pattern.
+func hostWildcards(host string) []string {
+	var names []string
+	for _, label := range strings.Split(host, ".") {
+		if name, ok := wildcardName(label); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// escapeSegments escapes the value as a path segment. When multi is true, the value is
+// escaped as a sequence of segments separated by slashes.
+func escapeSegments(value string, multi bool) string {
+	if !multi {
+		return url.PathEscape(value)
+	}
+
+	parts := strings.Split(value, "/")
+	for i, part := range parts {
+		parts[i] = url.PathEscape(part)
+	}
+	return strings.Join(parts, "/")
+}
+
+// mountedURL returns the path of the named route of a router attached with Mount.
+func (r *Router) mountedURL(name string, params []string) (string, error) {
+	for _, m := range r.core.mounts {
+		if m.sub == nil {
+			continue
+		}
+
+		u, err := m.sub.URL(name, params...)
+		if errors.Is(err, ErrRouteNotFound) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSuffix(m.path, "/") + u, nil
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrRouteNotFound, name)
+}
@@ -0,0 +1,144 @@
+package goexpress_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/ferdiebergado/goexpress"
+)
+
+func TestURL(t *testing.T) {
+	t.Parallel()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("ok"))
+	})
+
+	r := goexpress.New()
+	r.Get("/", handler).Name("home")
+	r.Get("/users/{$}", handler).Name("users")
+	r.Group("/users", func(users *goexpress.Router) {
+		users.Get("/{id}/posts", handler).Name("user-posts")
+		users.Get("/{id}/files/{path...}", handler).Name("user-file")
+	})
+	r.Get("/orders/{id:int}", handler).Name("order")
+
+	tests := []struct {
+		name    string
+		route   string
+		params  []string
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "static path",
+			route: "home",
+			want:  "/",
+		},
+		{
+			name:  "end of path wildcard",
+			route: "users",
+			want:  "/users/",
+		},
+		{
+			name:   "single wildcard in group",
+			route:  "user-posts",
+			params: []string{"id", "42"},
+			want:   "/users/42/posts",
+		},
+		{
+			name:   "escaped segment",
+			route:  "user-posts",
+			params: []string{"id", "a/b c"},
+			want:   "/users/a%2Fb%20c/posts",
+		},
+		{
+			name:   "remaining segments wildcard",
+			route:  "user-file",
+			params: []string{"id", "1", "path", "docs/report 1.pdf"},
+			want:   "/users/1/files/docs/report%201.pdf",
+		},
+		{
+			name:    "unknown route",
+			route:   "unknown",
+			wantErr: goexpress.ErrRouteNotFound,
+		},
+		{
+			name:    "missing parameter",
+			route:   "user-file",
+			params:  []string{"id", "1"},
+			wantErr: goexpress.ErrMissingParam,
+		},
+		{
+			name:    "empty parameter",
+			route:   "user-posts",
+			params:  []string{"id", ""},
+			wantErr: goexpress.ErrInvalidParam,
+		},
+		{
+			name:   "empty remaining segments",
+			route:  "user-file",
+			params: []string{"id", "1", "path", ""},
+			want:   "/users/1/files/",
+		},
+		{
+			name:   "constrained parameter",
+			route:  "order",
+			params: []string{"id", "42"},
+			want:   "/orders/42",
+		},
+		{
+			name:    "unsatisfied constraint",
+			route:   "order",
+			params:  []string{"id", "abc"},
+			wantErr: goexpress.ErrInvalidParam,
+		},
+		{
+			name:    "extra parameter",
+			route:   "user-posts",
+			params:  []string{"id", "1", "page", "2"},
+			wantErr: goexpress.ErrExtraParam,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := r.URL(tt.route, tt.params...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("r.URL(%q, %q) error = %v, want: %v", tt.route, tt.params, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("r.URL(%q, %q) = %q, want: %q", tt.route, tt.params, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestURLOddParams(t *testing.T) {
+	t.Parallel()
+
+	r := goexpress.New()
+	r.Get("/users/{id}", http.NotFoundHandler()).Name("user")
+
+	if _, err := r.URL("user", "id"); err == nil {
+		t.Error("r.URL with an odd number of parameters did not return an error")
+	}
+}
+
+func TestDuplicateRouteName(t *testing.T) {
+	t.Parallel()
+
+	r := goexpress.New()
+	r.Get("/users", http.NotFoundHandler()).Name("users")
+
+	defer func() {
+		if err := recover(); err == nil {
+			t.Error("duplicate route name did not panic")
+		}
+	}()
+
+	r.Post("/users", http.NotFoundHandler()).Name("users")
+}